@@ -0,0 +1,140 @@
+package main
+
+import "errors"
+
+// The error sent by signals returned from Action.Apply when the action is
+// disabled or already executing.
+var ErrDisabled = errors.New("action is disabled")
+
+// Represents a repeatable piece of work, which can only run one execution at
+// a time, and whose state can be observed.
+//
+// Disposing of an action stops it from observing its enabledIf property.
+type Action interface {
+	Disposable
+	Apply(T) Signal
+	Executing() Property
+	Enabled() Property
+	Values() Signal
+	Errors() Signal
+}
+
+type action struct {
+	execute   func(T) Signal
+	enabledIf Property
+	running   Atomic
+	executing MutableProperty
+	enabled   MutableProperty
+	values    Subject
+	errors    Subject
+	// Serializes the updates of `executing` and `enabled`, so that the last
+	// one always reflects the latest state.
+	refresher  Subscriber
+	disposable Disposable
+}
+
+// Creates an action that will run the signal returned by `execute` for each
+// application.
+func NewAction(execute func(T) Signal) Action {
+	return NewActionEnabledIf(NewMutableProperty(true), execute)
+}
+
+// Creates an action that will run the signal returned by `execute` for each
+// application, and is only enabled while `enabledIf` holds true.
+func NewActionEnabledIf(enabledIf Property, execute func(T) Signal) Action {
+	a := &action{
+		execute:   execute,
+		enabledIf: enabledIf,
+		running:   NewAtomic(false),
+		executing: NewMutableProperty(false),
+		enabled:   NewMutableProperty(enabledIf.Value() == true),
+		values:    NewPublishSubject(),
+		errors:    NewPublishSubject(),
+	}
+	a.refresher = NewSerializedSubscriber(NewSubscriber(func(_ T) {
+		running := a.running.Value().(bool)
+		a.executing.SetValue(running)
+		a.enabled.SetValue(a.enabledIf.Value() == true && running == false)
+	}, nil, nil))
+	a.disposable = enabledIf.Signal().SubscribeFunc(func(_ T) {
+		a.refresh()
+	}, nil, nil)
+	return a
+}
+
+// Updates `executing` and `enabled` from the current state.
+func (action *action) refresh() {
+	action.refresher.OnNext(nil)
+}
+
+func (action *action) Dispose() error {
+	return action.disposable.Dispose()
+}
+
+func (action *action) IsDisposed() bool {
+	return action.disposable.IsDisposed()
+}
+
+// Returns a signal that will execute the action with `input` upon
+// subscription, and forward its events.
+//
+// If the action is disabled or already executing at that time, the signal
+// errors with ErrDisabled instead.
+func (action *action) Apply(input T) Signal {
+	return NewSignal(func(subscriber Subscriber) {
+		_, started := action.running.ModifyData(func(running interface{}) (interface{}, interface{}) {
+			if running.(bool) || action.enabledIf.Value() != true {
+				return running, false
+			}
+			return true, true
+		})
+		if started == false {
+			subscriber.OnError(ErrDisabled)
+			return
+		}
+
+		action.refresh()
+
+		subscriber.Disposable().AddDisposableFunc(func() error {
+			action.running.SetValue(false)
+			action.refresh()
+			return nil
+		})
+
+		subscriber.Disposable().AddDisposable(action.execute(input).SubscribeFunc(
+			func(value T) {
+				action.values.OnNext(value)
+				subscriber.OnNext(value)
+			},
+			func(err error) {
+				action.errors.OnNext(err)
+				subscriber.OnError(err)
+			},
+			func() {
+				subscriber.OnCompleted()
+			},
+		))
+	})
+}
+
+// Whether the action is currently executing.
+func (action *action) Executing() Property {
+	return action.executing
+}
+
+// Whether the action can currently be applied: it must be enabled and not
+// executing.
+func (action *action) Enabled() Property {
+	return action.enabled
+}
+
+// A signal of all the values sent by the executions of the action.
+func (action *action) Values() Signal {
+	return action.values
+}
+
+// A signal of all the errors sent by the executions of the action, except
+// ErrDisabled.
+func (action *action) Errors() Signal {
+	return action.errors
+}
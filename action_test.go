@@ -0,0 +1,139 @@
+package main
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestActionShouldExecuteAndTrackState(t *testing.T) {
+	var execution Subscriber
+	action := NewAction(func(input T) Signal {
+		return NewSignal(func(s Subscriber) {
+			execution = s
+		}).StartWith(input)
+	})
+	values := make([]int, 0)
+
+	action.Values().SubscribeAuto(func(v int) {
+		values = append(values, v)
+	})
+	action.Apply(1).SubscribeAuto()
+
+	if action.Executing().Value() != true {
+		t.Error("Expect `action.Executing().Value()` to be true")
+	}
+	if action.Enabled().Value() != false {
+		t.Error("Expect `action.Enabled().Value()` to be false")
+	}
+
+	execution.OnNext(2)
+	execution.OnCompleted()
+
+	if action.Executing().Value() != false {
+		t.Error("Expect `action.Executing().Value()` to be false")
+	}
+	if action.Enabled().Value() != true {
+		t.Error("Expect `action.Enabled().Value()` to be true")
+	}
+	if len(values) != 2 || values[0] != 1 || values[1] != 2 {
+		t.Errorf("Expect `values` to equal [1 2], got %v", values)
+	}
+}
+
+func TestActionShouldErrorWhenBusy(t *testing.T) {
+	action := NewAction(func(_ T) Signal {
+		return NewNeverSignal()
+	})
+	var err error
+
+	action.Apply(nil).SubscribeAuto()
+	action.Apply(nil).SubscribeAuto(func(e error) {
+		err = e
+	})
+
+	if err != ErrDisabled {
+		t.Errorf("Expect `err` to equal ErrDisabled, got %v", err)
+	}
+}
+
+func TestActionShouldFollowEnabledProperty(t *testing.T) {
+	enabledIf := NewMutableProperty(false)
+	action := NewActionEnabledIf(enabledIf, func(_ T) Signal {
+		return NewErrorSignal(errors.New("failed"))
+	})
+	errs := make([]error, 0)
+
+	action.Errors().SubscribeFunc(func(e T) {
+		errs = append(errs, e.(error))
+	}, nil, nil)
+	action.Apply(nil).SubscribeAuto(func(e error) {
+		if e != ErrDisabled {
+			t.Errorf("Expect `e` to equal ErrDisabled, got %v", e)
+		}
+	})
+	if action.Enabled().Value() != false {
+		t.Error("Expect `action.Enabled().Value()` to be false")
+	}
+
+	enabledIf.SetValue(true)
+	if action.Enabled().Value() != true {
+		t.Error("Expect `action.Enabled().Value()` to be true")
+	}
+	action.Apply(nil).SubscribeAuto(func(e error) {})
+
+	if len(errs) != 1 {
+		t.Errorf("Expect `errs` to contain 1 error, got %v", errs)
+	}
+}
+
+func TestActionShouldTrackStateUnderConcurrentApplications(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		action := NewAction(func(input T) Signal {
+			if input == 0 {
+				return NewSingleSignal(0)
+			}
+			return NewNeverSignal()
+		})
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			action.Apply(0).SubscribeFunc(nil, nil, nil)
+			wg.Done()
+		}()
+		var running Disposable
+		for running == nil {
+			started := true
+			d := action.Apply(1).SubscribeFunc(nil, func(_ error) {
+				started = false
+			}, nil)
+			if started {
+				running = d
+			}
+		}
+		wg.Wait()
+
+		if action.Executing().Value() != true || action.Enabled().Value() != false {
+			t.Fatal("Expect the action to be executing while an execution is running")
+		}
+		running.Dispose()
+		if action.Executing().Value() != false || action.Enabled().Value() != true {
+			t.Fatal("Expect the action not to be executing once executions are disposed of")
+		}
+	}
+}
+
+func TestActionShouldStopFollowingEnabledPropertyOnceDisposed(t *testing.T) {
+	enabledIf := NewMutableProperty(true)
+	action := NewActionEnabledIf(enabledIf, func(_ T) Signal {
+		return NewEmptySignal()
+	})
+
+	action.Dispose()
+	enabledIf.SetValue(false)
+
+	if action.Enabled().Value() != true {
+		t.Error("Expect `action.Enabled().Value()` to be true")
+	}
+}
@@ -39,11 +39,13 @@ func (atomic *_atomic) SetValue(v interface{}) {
 //
 // Returns the old value, plus arbitrary user-defined data.
 func (atomic *_atomic) ModifyData(action func(interface{}) (interface{}, interface{})) (interface{}, interface{}) {
+	// The mutex is unlocked in a deferred call so that a panicking action,
+	// which may be recovered from, does not leave the variable locked.
 	atomic.mutex.Lock()
+	defer atomic.mutex.Unlock()
 	oldValue := atomic.value
 	newValue, data := action(atomic.value)
 	atomic.value = newValue
-	atomic.mutex.Unlock()
 	return oldValue, data
 }
 
@@ -72,7 +74,6 @@ func (atomic *_atomic) Swap(newValue interface{}) interface{} {
 // Returns the result of the action.
 func (atomic *_atomic) WithValue(action func(interface{}) interface{}) interface{} {
 	atomic.mutex.Lock()
-	result := action(atomic.value)
-	atomic.mutex.Unlock()
-	return result
+	defer atomic.mutex.Unlock()
+	return action(atomic.value)
 }
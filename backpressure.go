@@ -0,0 +1,216 @@
+package main
+
+import (
+	"errors"
+	"math"
+)
+
+// The error sent by signals returned from OnBackpressureBuffer with the
+// OverflowError strategy when their buffer is full.
+var ErrBufferOverflow = errors.New("backpressure buffer overflow")
+
+// What to do with a value that does not fit in a backpressure buffer.
+type OverflowStrategy int
+
+const (
+	// Error with ErrBufferOverflow, disposing of the source.
+	OverflowError OverflowStrategy = iota
+	// Drop the oldest buffered value to make room for the new one.
+	OverflowDropOldest
+	// Drop the new value.
+	OverflowDropLatest
+)
+
+// A subscription to a BackpressureSignal, which only receives as many values
+// as it requested.
+type Subscription interface {
+	Disposable
+	Request(int)
+}
+
+// A signal that only sends values to a subscriber as they are requested,
+// buffering or dropping the values its source pushes in the meantime.
+//
+// Subscribing with Subscribe, SubscribeFunc or SubscribeAuto requests an
+// unbounded number of values, so that BackpressureSignals can be used like
+// any other signal.
+type BackpressureSignal interface {
+	Signal
+	SubscribeWithDemand(Subscriber, int) Subscription
+}
+
+type backpressureSignal struct {
+	Signal
+	source   Signal
+	size     int
+	strategy OverflowStrategy
+}
+
+// Creates a backpressure signal that buffers at most `size` values from
+// `source` beyond the requested ones, applying `strategy` to the values that
+// do not fit. A negative `size` means the buffer is unbounded.
+func newBackpressureSignal(source Signal, size int, strategy OverflowStrategy) BackpressureSignal {
+	s := &backpressureSignal{source: source, size: size, strategy: strategy}
+	s.Signal = NewSignal(func(subscriber Subscriber) {
+		s.SubscribeWithDemand(subscriber, math.MaxInt)
+	})
+	return s
+}
+
+type backpressureState struct {
+	queue    []T
+	demand   int
+	terminal *Notification
+	// The number of times draining was requested while a goroutine was
+	// already draining, or 0 if nobody is draining. It is never reset once
+	// the terminal event was sent, so that nothing is sent afterward.
+	draining int
+}
+
+type subscription struct {
+	subscriber Subscriber
+	state      Atomic
+}
+
+// Starts producing events for the given subscriber, sending at most
+// `initial` values until more are requested with the returned Subscription.
+//
+// `initial` may be 0, but synchronous sources will then have sent all their
+// values by the time more can be requested.
+func (signal *backpressureSignal) SubscribeWithDemand(subscriber Subscriber, initial int) Subscription {
+	if initial < 0 {
+		panic("BackpressureSignal.SubscribeWithDemand: initial parameter should be >= 0")
+	}
+	s := &subscription{subscriber, NewAtomic(backpressureState{demand: initial})}
+
+	var sourceSubscriber Subscriber
+	sourceSubscriber = NewSubscriber(
+		func(value T) {
+			_, overflowed := s.state.ModifyData(func(st interface{}) (interface{}, interface{}) {
+				state := st.(backpressureState)
+				if state.terminal != nil {
+					return state, false
+				}
+				// Compared without adding `demand` and `size`, which would
+				// overflow for unbounded demands.
+				if signal.size < 0 || state.demand == math.MaxInt || len(state.queue)-state.demand < signal.size {
+					state.queue = append(state.queue, value)
+					return state, false
+				}
+				switch signal.strategy {
+				case OverflowDropOldest:
+					if len(state.queue) > 0 {
+						state.queue = append(state.queue[1:], value)
+					}
+				case OverflowError:
+					terminal := NewErrorNotification(ErrBufferOverflow)
+					state.queue = nil
+					state.terminal = &terminal
+					return state, true
+				}
+				return state, false
+			})
+			if overflowed.(bool) {
+				sourceSubscriber.Disposable().Dispose()
+			}
+			s.drain()
+		},
+		func(err error) {
+			s.terminate(NewErrorNotification(err))
+		},
+		func() {
+			s.terminate(NewCompletedNotification())
+		},
+	)
+	subscriber.Disposable().AddDisposable(sourceSubscriber.Disposable())
+	signal.source.Subscribe(sourceSubscriber)
+
+	return s
+}
+
+func (s *subscription) terminate(notification Notification) {
+	s.state.Modify(func(st interface{}) interface{} {
+		state := st.(backpressureState)
+		if state.terminal == nil {
+			state.terminal = &notification
+		}
+		return state
+	})
+	s.drain()
+}
+
+// Requests `n` more values. Requests add up, and are capped to math.MaxInt,
+// which means an unbounded number of values.
+func (s *subscription) Request(n int) {
+	if n <= 0 {
+		panic("Subscription.Request: n parameter should be > 0")
+	}
+	s.state.Modify(func(st interface{}) interface{} {
+		state := st.(backpressureState)
+		if state.demand > math.MaxInt-n {
+			state.demand = math.MaxInt
+		} else {
+			state.demand += n
+		}
+		return state
+	})
+	s.drain()
+}
+
+func (s *subscription) Dispose() error {
+	return s.subscriber.Disposable().Dispose()
+}
+
+func (s *subscription) IsDisposed() bool {
+	return s.subscriber.Disposable().IsDisposed()
+}
+
+// Sends as many buffered values as requested, followed by the terminal event
+// once the buffer is empty.
+//
+// Only one goroutine drains at a time: others only record that there may be
+// more to send, and the draining goroutine loops until there is not.
+func (s *subscription) drain() {
+	_, busy := s.state.ModifyData(func(st interface{}) (interface{}, interface{}) {
+		state := st.(backpressureState)
+		state.draining++
+		return state, state.draining > 1
+	})
+	if busy.(bool) {
+		return
+	}
+
+	for {
+		_, next := s.state.ModifyData(func(st interface{}) (interface{}, interface{}) {
+			state := st.(backpressureState)
+			if len(state.queue) > 0 && state.demand > 0 {
+				value := state.queue[0]
+				state.queue = state.queue[1:]
+				if state.demand != math.MaxInt {
+					state.demand--
+				}
+				return state, NewNextNotification(value)
+			}
+			if len(state.queue) == 0 && state.terminal != nil {
+				return state, *state.terminal
+			}
+			if state.draining > 1 {
+				state.draining = 1
+				return state, true
+			}
+			state.draining = 0
+			return state, nil
+		})
+		switch n := next.(type) {
+		case Notification:
+			n.Accept(s.subscriber)
+			if n.IsTerminating() {
+				return
+			}
+		case bool:
+			continue
+		default:
+			return
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestOnBackpressureBufferShouldOnlySendRequestedValues(t *testing.T) {
+	result := make([]int, 0)
+	completed := false
+
+	subscription := NewValuesSignal([]interface{}{1, 2, 3, 4, 5}).OnBackpressureBuffer(0, OverflowError).SubscribeWithDemand(NewSubscriber(func(v T) {
+		result = append(result, v.(int))
+	}, nil, func() {
+		completed = true
+	}), 0)
+
+	if len(result) != 0 {
+		t.Fatalf("Expecting `len(result)` to equal 0 got %v", len(result))
+	}
+	subscription.Request(2)
+	if len(result) != 2 {
+		t.Fatalf("Expecting `len(result)` to equal 2 got %v", len(result))
+	}
+	if completed != false {
+		t.Error("Expect `completed` to be false")
+	}
+	subscription.Request(3)
+	if len(result) != 5 {
+		t.Fatalf("Expecting `len(result)` to equal 5 got %v", len(result))
+	}
+	if completed != true {
+		t.Error("Expect `completed` to be true")
+	}
+}
+
+func TestOnBackpressureBufferShouldApplyOverflowStrategy(t *testing.T) {
+	source := NewValuesSignal([]interface{}{1, 2, 3, 4, 5})
+	dropOldest := make([]int, 0)
+	dropLatest := make([]int, 0)
+	var err error
+
+	source.OnBackpressureBuffer(2, OverflowDropOldest).SubscribeWithDemand(NewSubscriber(func(v T) {
+		dropOldest = append(dropOldest, v.(int))
+	}, nil, nil), 0).Request(10)
+	source.OnBackpressureBuffer(2, OverflowDropLatest).SubscribeWithDemand(NewSubscriber(func(v T) {
+		dropLatest = append(dropLatest, v.(int))
+	}, nil, nil), 0).Request(10)
+	source.OnBackpressureBuffer(2, OverflowError).SubscribeWithDemand(NewSubscriber(nil, func(e error) {
+		err = e
+	}, nil), 0).Request(10)
+
+	if len(dropOldest) != 2 || dropOldest[0] != 4 || dropOldest[1] != 5 {
+		t.Errorf("Expect `dropOldest` to equal [4 5], got %v", dropOldest)
+	}
+	if len(dropLatest) != 2 || dropLatest[0] != 1 || dropLatest[1] != 2 {
+		t.Errorf("Expect `dropLatest` to equal [1 2], got %v", dropLatest)
+	}
+	if err != ErrBufferOverflow {
+		t.Errorf("Expect `err` to equal ErrBufferOverflow, got %v", err)
+	}
+}
+
+func TestOnBackpressureDropAndLatest(t *testing.T) {
+	var source Subscriber
+	signal := NewSignal(func(s Subscriber) {
+		source = s
+	}).Share()
+	dropped := make([]int, 0)
+	latest := make([]int, 0)
+
+	drop := signal.OnBackpressureDrop().SubscribeWithDemand(NewSubscriber(func(v T) {
+		dropped = append(dropped, v.(int))
+	}, nil, nil), 0)
+	last := signal.OnBackpressureLatest().SubscribeWithDemand(NewSubscriber(func(v T) {
+		latest = append(latest, v.(int))
+	}, nil, nil), 0)
+
+	source.OnNext(1)
+	source.OnNext(2)
+	drop.Request(1)
+	last.Request(1)
+	source.OnNext(3)
+	source.OnNext(4)
+
+	if len(dropped) != 1 || dropped[0] != 3 {
+		t.Errorf("Expect `dropped` to equal [3], got %v", dropped)
+	}
+	if len(latest) != 1 || latest[0] != 2 {
+		t.Errorf("Expect `latest` to equal [2], got %v", latest)
+	}
+	last.Request(1)
+	if len(latest) != 2 || latest[1] != 4 {
+		t.Errorf("Expect `latest` to equal [2 4], got %v", latest)
+	}
+}
+
+func TestBackpressureSignalShouldBehaveAsSignal(t *testing.T) {
+	source := NewValuesSignal([]interface{}{1, 2, 3})
+	signals := map[string]Signal{
+		"Drop":         source.OnBackpressureDrop(),
+		"Latest":       source.OnBackpressureLatest(),
+		"Buffer":       source.OnBackpressureBuffer(10, OverflowError),
+		"BufferOldest": source.OnBackpressureBuffer(1, OverflowDropOldest),
+	}
+
+	for name, signal := range signals {
+		result := make([]int, 0)
+		var err error
+		signal.SubscribeFunc(func(v T) {
+			result = append(result, v.(int))
+		}, func(e error) {
+			err = e
+		}, nil)
+
+		if len(result) != 3 || err != nil {
+			t.Errorf("Expect `result` to equal [1 2 3] for %v, got %v (error %v)", name, result, err)
+		}
+	}
+}
@@ -0,0 +1,250 @@
+package main
+
+// A signal that shares a single subscription to its source among all of its
+// subscribers, once connected.
+//
+// Subscribing to a ConnectableSignal does not subscribe to the source: events
+// only start flowing once Connect is called.
+type ConnectableSignal interface {
+	Signal
+	Connect() Disposable
+	RefCount() Signal
+}
+
+type connectableState struct {
+	subject    Subject
+	connection Disposable
+}
+
+type connectableSignal struct {
+	Signal
+	source     Signal
+	newSubject func() Subject
+	state      Atomic
+}
+
+// Creates a connectable signal that multicasts events from `source` through
+// subjects created with `newSubject`.
+//
+// A new subject is created for the first connection and whenever connecting
+// again after the previous subject has terminated.
+func NewConnectableSignal(source Signal, newSubject func() Subject) ConnectableSignal {
+	connectable := &connectableSignal{
+		source:     source,
+		newSubject: newSubject,
+		state:      NewAtomic(connectableState{subject: newSubject()}),
+	}
+	connectable.Signal = NewSignal(func(subscriber Subscriber) {
+		connectable.state.Value().(connectableState).subject.Subscribe(subscriber)
+	})
+	return connectable
+}
+
+// Subscribes the underlying subject to the source, if not already connected.
+//
+// Returns a Disposable which will disconnect from the source. Subscribers are
+// not notified of the disconnection, but stop receiving events.
+func (connectable *connectableSignal) Connect() Disposable {
+	connection := NewSerialDisposable(nil)
+	_, current := connectable.state.ModifyData(func(s interface{}) (interface{}, interface{}) {
+		state := s.(connectableState)
+		if state.connection != nil {
+			return state, state.connection
+		}
+		subject := state.subject
+		if subject.Disposable().IsDisposed() {
+			subject = connectable.newSubject()
+		}
+		return connectableState{subject, connection}, subject
+	})
+	if existing, ok := current.(Disposable); ok {
+		return existing
+	}
+
+	subject := current.(Subject)
+	subscriber := NewSubscriber(subject.OnNext, subject.OnError, subject.OnCompleted)
+	subscriber.Disposable().AddDisposableFunc(func() error {
+		connectable.state.Modify(func(s interface{}) interface{} {
+			state := s.(connectableState)
+			if state.connection != connection {
+				return state
+			}
+			return connectableState{state.subject, nil}
+		})
+		return nil
+	})
+	connection.SetInnerDisposable(subscriber.Disposable())
+	connectable.source.Subscribe(subscriber)
+	return connection
+}
+
+// Replaces the subject with a new one if it has terminated and the receiver
+// is not connected, so that new subscribers receive the events of the next
+// connection instead of the terminal event of the previous one.
+func (connectable *connectableSignal) reset() {
+	connectable.state.Modify(func(s interface{}) interface{} {
+		state := s.(connectableState)
+		if state.connection != nil || state.subject.Disposable().IsDisposed() == false {
+			return state
+		}
+		return connectableState{connectable.newSubject(), nil}
+	})
+}
+
+type refCountState struct {
+	count      int
+	connection Disposable
+}
+
+// Returns a signal that connects the receiver when it gets its first
+// subscriber, and disconnects it once all subscribers have been disposed of.
+//
+// A first subscriber arriving after the source terminated triggers a new
+// connection with a fresh subject, rather than receiving the previous
+// terminal event.
+func (connectable *connectableSignal) RefCount() Signal {
+	state := NewAtomic(refCountState{})
+	return NewSignal(func(subscriber Subscriber) {
+		orig := state.Modify(func(s interface{}) interface{} {
+			return refCountState{s.(refCountState).count + 1, s.(refCountState).connection}
+		})
+
+		if orig.(refCountState).count == 0 {
+			connectable.reset()
+		}
+		connectable.Subscribe(subscriber)
+
+		if orig.(refCountState).count == 0 {
+			connection := connectable.Connect()
+			_, stale := state.ModifyData(func(s interface{}) (interface{}, interface{}) {
+				if s.(refCountState).count == 0 {
+					return s, true
+				}
+				return refCountState{s.(refCountState).count, connection}, false
+			})
+			if stale.(bool) {
+				connection.Dispose()
+			}
+		}
+
+		subscriber.Disposable().AddDisposableFunc(func() error {
+			_, connection := state.ModifyData(func(s interface{}) (interface{}, interface{}) {
+				rc := s.(refCountState)
+				if rc.count == 1 {
+					return refCountState{}, rc.connection
+				}
+				return refCountState{rc.count - 1, rc.connection}, nil
+			})
+			if connection != nil {
+				return connection.(Disposable).Dispose()
+			}
+			return nil
+		})
+	})
+}
+
+// A signal that subscribes to its source once, on its first subscriber, and
+// replays all the events of that subscription to every subscriber.
+//
+// Errors are cached like values: once the source has failed, new subscribers
+// receive its error until Invalidate is called.
+type CachedSignal interface {
+	Signal
+	Invalidate()
+}
+
+type cacheEntry struct {
+	connectable ConnectableSignal
+	state       Atomic
+}
+
+// The connection of a cache entry is disposed of once the entry has been
+// invalidated and has no subscribers left.
+type cacheEntryState struct {
+	subscribers int
+	connected   bool
+	connection  Disposable
+	invalidated bool
+}
+
+type cachedSignal struct {
+	Signal
+	source Signal
+	entry  Atomic
+}
+
+func newCacheEntry(source Signal) *cacheEntry {
+	return &cacheEntry{
+		connectable: NewConnectableSignal(source, func() Subject {
+			return NewReplaySubject(0, 0, nil)
+		}),
+		state: NewAtomic(cacheEntryState{}),
+	}
+}
+
+// Applies `update` to the state of the entry, then disposes of its connection
+// if it is no longer needed.
+func (entry *cacheEntry) update(update func(*cacheEntryState)) {
+	_, connection := entry.state.ModifyData(func(s interface{}) (interface{}, interface{}) {
+		state := s.(cacheEntryState)
+		update(&state)
+		if state.invalidated && state.subscribers == 0 && state.connection != nil {
+			connection := state.connection
+			state.connection = nil
+			return state, connection
+		}
+		return state, nil
+	})
+	if connection != nil {
+		connection.(Disposable).Dispose()
+	}
+}
+
+func (entry *cacheEntry) subscribe(subscriber Subscriber) {
+	_, connect := entry.state.ModifyData(func(s interface{}) (interface{}, interface{}) {
+		state := s.(cacheEntryState)
+		state.subscribers++
+		connect := state.connected == false
+		state.connected = true
+		return state, connect
+	})
+
+	entry.connectable.Subscribe(subscriber)
+	if connect.(bool) {
+		connection := entry.connectable.Connect()
+		entry.update(func(state *cacheEntryState) {
+			state.connection = connection
+		})
+	}
+
+	subscriber.Disposable().AddDisposableFunc(func() error {
+		entry.update(func(state *cacheEntryState) {
+			state.subscribers--
+		})
+		return nil
+	})
+}
+
+// Creates a cached signal of the given source.
+func NewCachedSignal(source Signal) CachedSignal {
+	cached := &cachedSignal{
+		source: source,
+		entry:  NewAtomic(newCacheEntry(source)),
+	}
+	cached.Signal = NewSignal(func(subscriber Subscriber) {
+		cached.entry.Value().(*cacheEntry).subscribe(subscriber)
+	})
+	return cached
+}
+
+// Drops the cached events, so that the next subscriber triggers a new
+// subscription to the source.
+//
+// Current subscribers keep receiving events from the previous subscription,
+// which is disposed of once they have all been disposed of.
+func (cached *cachedSignal) Invalidate() {
+	previous := cached.entry.Swap(newCacheEntry(cached.source)).(*cacheEntry)
+	previous.update(func(state *cacheEntryState) {
+		state.invalidated = true
+	})
+}
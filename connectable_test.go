@@ -0,0 +1,231 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestPublishShouldShareSubscriptionOnceConnected(t *testing.T) {
+	subscriptions := 0
+	connectable := NewValuesSignal([]interface{}{1, 2}).OnSubscribe(func() {
+		subscriptions++
+	}).Publish()
+	first := make([]int, 0)
+	second := make([]int, 0)
+
+	connectable.SubscribeAuto(func(v int) {
+		first = append(first, v)
+	})
+	connectable.SubscribeAuto(func(v int) {
+		second = append(second, v)
+	})
+	if subscriptions != 0 {
+		t.Errorf("Expect `subscriptions` to equal 0, got %v", subscriptions)
+	}
+
+	connectable.Connect()
+	if subscriptions != 1 {
+		t.Errorf("Expect `subscriptions` to equal 1, got %v", subscriptions)
+	}
+	if len(first) != 2 || len(second) != 2 {
+		t.Errorf("Expect both subscribers to receive 2 values, got %v and %v", first, second)
+	}
+}
+
+func TestConnectShouldReturnExistingConnection(t *testing.T) {
+	var source Subscriber
+	connectable := NewSignal(func(s Subscriber) {
+		source = s
+	}).Publish()
+
+	connection := connectable.Connect()
+	if connectable.Connect() != connection {
+		t.Error("Expect `connectable.Connect()` to return the existing connection")
+	}
+
+	connection.Dispose()
+	if source.Disposable().IsDisposed() != true {
+		t.Error("Expect `source.Disposable().IsDisposed()` to be true")
+	}
+	if connectable.Connect() == connection {
+		t.Error("Expect `connectable.Connect()` to create a new connection after disconnection")
+	}
+}
+
+func TestRefCountShouldConnectOnFirstAndDisconnectOnLastSubscriber(t *testing.T) {
+	subscriptions := 0
+	var source Subscriber
+	shared := NewSignal(func(s Subscriber) {
+		source = s
+	}).OnSubscribe(func() {
+		subscriptions++
+	}).Share()
+	result := make([]int, 0)
+
+	first := shared.SubscribeAuto(func(v int) {
+		result = append(result, v)
+	})
+	second := shared.SubscribeAuto(func(v int) {
+		result = append(result, v)
+	})
+	source.OnNext(1)
+
+	if subscriptions != 1 {
+		t.Errorf("Expect `subscriptions` to equal 1, got %v", subscriptions)
+	}
+	if len(result) != 2 {
+		t.Errorf("Expect `result` to equal [1 1], got %v", result)
+	}
+
+	first.Dispose()
+	if source.Disposable().IsDisposed() != false {
+		t.Error("Expect `source.Disposable().IsDisposed()` to be false")
+	}
+	second.Dispose()
+	if source.Disposable().IsDisposed() != true {
+		t.Error("Expect `source.Disposable().IsDisposed()` to be true")
+	}
+
+	shared.SubscribeAuto()
+	if subscriptions != 2 {
+		t.Errorf("Expect `subscriptions` to equal 2, got %v", subscriptions)
+	}
+}
+
+func TestShareShouldResubscribeAfterCompletion(t *testing.T) {
+	runs := 0
+	shared := NewSignal(func(s Subscriber) {
+		runs++
+		s.OnNext(runs)
+		s.OnCompleted()
+	}).Share()
+	result := make([]int, 0)
+	expected := []int{1, 2}
+
+	shared.SubscribeAuto(func(v int) {
+		result = append(result, v)
+	})
+	shared.SubscribeAuto(func(v int) {
+		result = append(result, v)
+	})
+
+	if runs != 2 {
+		t.Errorf("Expect `runs` to equal 2, got %v", runs)
+	}
+	if len(result) != len(expected) {
+		t.Fatalf("Expecting `len(result)` to equal %v got %v", len(expected), len(result))
+	}
+	for i, v := range expected {
+		if v != result[i] {
+			t.Fatalf("Expecting %v to equal %v", result, expected)
+		}
+	}
+}
+
+func TestReplayShouldReplayBufferToLateSubscribers(t *testing.T) {
+	connectable := NewValuesSignal([]interface{}{1, 2, 3}).Replay(2, 0, nil)
+	result := make([]int, 0)
+	expected := []int{2, 3}
+	completed := false
+
+	connectable.Connect()
+	connectable.SubscribeAuto(func(v int) {
+		result = append(result, v)
+	}, func() {
+		completed = true
+	})
+
+	if completed != true {
+		t.Error("Expect `completed` to be true")
+	}
+	if len(result) != len(expected) {
+		t.Fatalf("Expecting `len(result)` to equal %v got %v", len(expected), len(result))
+	}
+	for i, v := range expected {
+		if v != result[i] {
+			t.Fatalf("Expecting %v to equal %v", result, expected)
+		}
+	}
+}
+
+func TestCacheShouldSubscribeOnceUntilInvalidated(t *testing.T) {
+	fetches := 0
+	cached := NewSignal(func(s Subscriber) {
+		fetches++
+		s.OnNext(fetches)
+		s.OnCompleted()
+	}).Cache()
+	result := make([]int, 0)
+	expected := []int{1, 1, 2}
+
+	cached.SubscribeAuto(func(v int) {
+		result = append(result, v)
+	})
+	cached.SubscribeAuto(func(v int) {
+		result = append(result, v)
+	})
+	cached.Invalidate()
+	cached.SubscribeAuto(func(v int) {
+		result = append(result, v)
+	})
+
+	if fetches != 2 {
+		t.Errorf("Expect `fetches` to equal 2, got %v", fetches)
+	}
+	if len(result) != len(expected) {
+		t.Fatalf("Expecting `len(result)` to equal %v got %v", len(expected), len(result))
+	}
+	for i, v := range expected {
+		if v != result[i] {
+			t.Fatalf("Expecting %v to equal %v", result, expected)
+		}
+	}
+}
+
+func TestCacheShouldDisposeOfInvalidatedSubscriptions(t *testing.T) {
+	disposed := 0
+	cached := NewSignal(func(s Subscriber) {
+		s.Disposable().AddDisposableFunc(func() error {
+			disposed++
+			return nil
+		})
+		s.OnNext(1)
+	}).Cache()
+
+	first := cached.SubscribeAuto()
+	second := cached.SubscribeAuto()
+	cached.Invalidate()
+	first.Dispose()
+	if disposed != 0 {
+		t.Errorf("Expect `disposed` to equal 0 while subscribed, got %v", disposed)
+	}
+	second.Dispose()
+	if disposed != 1 {
+		t.Errorf("Expect `disposed` to equal 1, got %v", disposed)
+	}
+
+	third := cached.SubscribeAuto()
+	cached.Invalidate()
+	third.Dispose()
+	if disposed != 2 {
+		t.Errorf("Expect `disposed` to equal 2, got %v", disposed)
+	}
+}
+
+func TestCacheShouldDisposeOfUnusedSubscriptionOnInvalidate(t *testing.T) {
+	disposed := false
+	cached := NewSignal(func(s Subscriber) {
+		s.Disposable().AddDisposableFunc(func() error {
+			disposed = true
+			return nil
+		})
+	}).Cache()
+
+	cached.SubscribeAuto().Dispose()
+	if disposed != false {
+		t.Error("Expect the cached subscription to outlive its subscribers")
+	}
+	cached.Invalidate()
+	if disposed != true {
+		t.Error("Expect `disposed` to be true")
+	}
+}
@@ -1,5 +1,10 @@
 package main
 
+import (
+	"container/list"
+	"strings"
+)
+
 // Represents something that can be “disposed”, usually associated with freeing
 // resources or canceling work.
 type Disposable interface {
@@ -50,14 +55,59 @@ func NewActionDisposable(action func() error) Disposable {
 	return disposable
 }
 
+// A disposable that failed to dispose, along with the error it returned.
+type DisposeFailure struct {
+	Disposable Disposable
+	Err        error
+}
+
+// The error returned by CompositeDisposable.Dispose when some of its
+// disposables failed.
+//
+// Like errors created with `errors.Join`, it wraps all of their errors, so
+// that `errors.Is` and `errors.As` match any of them.
+type DisposeError struct {
+	Failures []DisposeFailure
+}
+
+func (err *DisposeError) Error() string {
+	messages := make([]string, 0, len(err.Failures))
+	for _, f := range err.Failures {
+		messages = append(messages, f.Err.Error())
+	}
+	return strings.Join(messages, "\n")
+}
+
+func (err *DisposeError) Unwrap() []error {
+	errs := make([]error, 0, len(err.Failures))
+	for _, f := range err.Failures {
+		errs = append(errs, f.Err)
+	}
+	return errs
+}
+
 // A disposable that will dispose of any number of other disposables.
+//
+// Added disposables are disposed of in the order they were added.
 type CompositeDisposable interface {
 	Disposable
 	AddDisposable(Disposable) error
 	AddDisposableFunc(func() error) error
+	Add(Disposable) CompositeHandle
+	Remove(CompositeHandle)
 	PruneDisposed()
 }
 
+// Identifies a disposable added to a CompositeDisposable with Add, so that it
+// can be removed in constant time.
+//
+// The zero value identifies no disposable.
+type CompositeHandle struct {
+	element *list.Element
+}
+
+// The disposables are kept in a list, which is only accessed while holding
+// the Atomic, and is replaced by nil once the composite is disposed of.
 type compositeDisposable struct {
 	disposables Atomic
 }
@@ -66,58 +116,113 @@ func (disposable *compositeDisposable) IsDisposed() bool {
 	return disposable.disposables.Value() == nil
 }
 
+// Disposes of every added disposable, even if some of them fail.
+//
+// Returns a *DisposeError listing the disposables that failed, if any.
 func (disposable *compositeDisposable) Dispose() error {
 	ds := disposable.disposables.Swap(nil)
-	var err error
-	if ds != nil {
-		for _, d := range ds.([]Disposable) {
-			err = d.Dispose()
+	if ds == nil {
+		return nil
+	}
+	var failures []DisposeFailure
+	for e := ds.(*list.List).Front(); e != nil; e = e.Next() {
+		d := e.Value.(Disposable)
+		if err := d.Dispose(); err != nil {
+			failures = append(failures, DisposeFailure{d, err})
 		}
 	}
-	return err
+	if len(failures) == 0 {
+		return nil
+	}
+	return &DisposeError{failures}
 }
 
+// Adds a disposable to be disposed of along with the receiver, or disposes
+// of it right away if the receiver was already disposed of.
+//
+// Returns the error of that immediate disposal, if any.
 func (disposable *compositeDisposable) AddDisposable(d Disposable) error {
-	if d == nil {
+	_, err := disposable.add(d)
+	return err
+}
+
+func (disposable *compositeDisposable) AddDisposableFunc(action func() error) error {
+	if action == nil {
 		return nil
 	}
+	return disposable.AddDisposable(NewActionDisposable(action))
+}
 
-	_, shouldDispose := disposable.disposables.ModifyData(func(ds interface{}) (interface{}, interface{}) {
-		if ds != nil {
-			return append(ds.([]Disposable), d), false
-		} else {
-			return nil, true
+// Like AddDisposable, but returns a handle to remove the disposable with
+// Remove.
+//
+// If the receiver was already disposed of, the disposable is disposed of
+// right away, and the zero handle is returned.
+func (disposable *compositeDisposable) Add(d Disposable) CompositeHandle {
+	handle, _ := disposable.add(d)
+	return handle
+}
+
+func (disposable *compositeDisposable) add(d Disposable) (CompositeHandle, error) {
+	if d == nil {
+		return CompositeHandle{}, nil
+	}
+
+	_, element := disposable.disposables.ModifyData(func(ds interface{}) (interface{}, interface{}) {
+		if ds == nil {
+			return nil, nil
 		}
+		return ds, ds.(*list.List).PushBack(d)
 	})
 
-	if shouldDispose == true {
-		return d.Dispose()
+	if element == nil {
+		return CompositeHandle{}, d.Dispose()
 	}
 
-	return nil
+	return CompositeHandle{element.(*list.Element)}, nil
 }
 
-func (disposable *compositeDisposable) AddDisposableFunc(action func() error) error {
-	if action == nil {
-		return nil
+// Removes the disposable identified by `handle` without disposing of it, in
+// constant time.
+//
+// Does nothing if it was already removed, or if the receiver was already
+// disposed of.
+func (disposable *compositeDisposable) Remove(handle CompositeHandle) {
+	if handle.element == nil {
+		return
 	}
-	return disposable.AddDisposable(NewActionDisposable(action))
+	disposable.disposables.Modify(func(ds interface{}) interface{} {
+		if ds != nil {
+			// Does nothing if the element is not in this list.
+			ds.(*list.List).Remove(handle.element)
+		}
+		return ds
+	})
 }
 
+// Removes every added disposable that was already disposed of.
+//
+// This takes time proportional to the number of added disposables: prefer
+// Remove when the disposable was added with Add.
 func (disposable *compositeDisposable) PruneDisposed() {
 	disposable.disposables.Modify(func(ds interface{}) interface{} {
-		filteredDisposables := make([]Disposable, 0, len(ds.([]Disposable)))
-		for _, d := range ds.([]Disposable) {
-			if d.IsDisposed() == false {
-				filteredDisposables = append(filteredDisposables, d)
+		if ds == nil {
+			return nil
+		}
+		l := ds.(*list.List)
+		for e := l.Front(); e != nil; {
+			next := e.Next()
+			if e.Value.(Disposable).IsDisposed() {
+				l.Remove(e)
 			}
+			e = next
 		}
-		return filteredDisposables
+		return l
 	})
 }
 
 func NewCompositeDisposable(action func() error) CompositeDisposable {
-	disposable := &compositeDisposable{disposables: NewAtomic(make([]Disposable, 0, 1))}
+	disposable := &compositeDisposable{NewAtomic(list.New())}
 	disposable.AddDisposableFunc(action)
 	return disposable
 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"testing"
 )
 
@@ -99,6 +100,135 @@ func TestCompositeDisposableShouldNotPruneActiveDisposables(t *testing.T) {
 	}
 }
 
+func TestCompositeDisposableShouldCollectAllErrors(t *testing.T) {
+	first := errors.New("first")
+	second := errors.New("second")
+	disposable := NewCompositeDisposable(nil)
+
+	failing := NewActionDisposable(func() error {
+		return first
+	})
+	disposable.AddDisposable(failing)
+	simpleDisposable := NewSimpleDisposable()
+	disposable.AddDisposable(simpleDisposable)
+	disposable.AddDisposableFunc(func() error {
+		return second
+	})
+
+	err := disposable.Dispose()
+	if simpleDisposable.IsDisposed() != true {
+		t.Error("Expect `simpleDisposable.IsDisposed()` to be true")
+	}
+	var disposeErr *DisposeError
+	if !errors.As(err, &disposeErr) {
+		t.Fatalf("Expect `err` to be a DisposeError, got %v", err)
+	}
+	if len(disposeErr.Failures) != 2 {
+		t.Fatalf("Expecting `len(disposeErr.Failures)` to equal 2 got %v", len(disposeErr.Failures))
+	}
+	if disposeErr.Failures[0].Disposable != failing {
+		t.Error("Expect the first failure to be `failing`")
+	}
+	if !errors.Is(err, first) || !errors.Is(err, second) {
+		t.Errorf("Expect `err` to wrap both errors, got %v", err)
+	}
+}
+
+func TestSubscriberDisposableShouldCollectNestedErrors(t *testing.T) {
+	failure := errors.New("failed")
+	disposable := NewSignal(func(subscriber Subscriber) {
+		subscriber.Disposable().AddDisposableFunc(func() error {
+			return failure
+		})
+	}).Map(func(v T) U {
+		return v
+	}).SubscribeFunc(nil, nil, nil)
+
+	if err := disposable.Dispose(); !errors.Is(err, failure) {
+		t.Errorf("Expect `err` to wrap %v, got %v", failure, err)
+	}
+}
+
+func TestCompositeDisposableShouldNotDisposeRemovedDisposables(t *testing.T) {
+	disposable := NewCompositeDisposable(nil)
+	other := NewCompositeDisposable(nil)
+
+	removed := NewSimpleDisposable()
+	kept := NewSimpleDisposable()
+	handle := disposable.Add(removed)
+	keptHandle := disposable.Add(kept)
+	disposable.Remove(handle)
+	disposable.Remove(handle)
+	disposable.Remove(CompositeHandle{})
+	other.Remove(keptHandle)
+
+	disposable.Dispose()
+	if removed.IsDisposed() != false {
+		t.Error("Expect `removed.IsDisposed()` to be false")
+	}
+	if kept.IsDisposed() != true {
+		t.Error("Expect `kept.IsDisposed()` to be true")
+	}
+
+	disposable.Remove(keptHandle)
+	disposable.PruneDisposed()
+}
+
+// Disposables need not be comparable.
+type disposeFunc func() error
+
+func (f disposeFunc) Dispose() error {
+	return f()
+}
+
+func (f disposeFunc) IsDisposed() bool {
+	return false
+}
+
+func TestCompositeDisposableShouldAcceptNonComparableDisposables(t *testing.T) {
+	disposable := NewCompositeDisposable(nil)
+	didDispose := false
+
+	disposable.AddDisposable(disposeFunc(func() error {
+		didDispose = true
+		return nil
+	}))
+	disposable.Remove(disposable.Add(disposeFunc(func() error {
+		return nil
+	})))
+	disposable.Dispose()
+
+	if didDispose != true {
+		t.Error("Expect `didDispose` to be true")
+	}
+}
+
+func TestCompositeDisposableShouldDisposeInInsertionOrder(t *testing.T) {
+	result := make([]int, 0)
+	expected := []int{1, 2, 3}
+	disposable := NewCompositeDisposable(func() error {
+		result = append(result, 1)
+		return nil
+	})
+	for _, v := range expected[1:] {
+		v := v
+		disposable.AddDisposableFunc(func() error {
+			result = append(result, v)
+			return nil
+		})
+	}
+
+	disposable.Dispose()
+	if len(result) != len(expected) {
+		t.Fatalf("Expecting `len(result)` to equal %v got %v", len(expected), len(result))
+	}
+	for i := range expected {
+		if result[i] != expected[i] {
+			t.Errorf("Expect `result[%d]` to equal %v, got %v", i, expected[i], result[i])
+		}
+	}
+}
+
 func TestSerialDisposableShouldDisposeOfInnerDisposable(t *testing.T) {
 	disposable := NewSerialDisposable(nil)
 
@@ -0,0 +1,41 @@
+package main
+
+import "container/list"
+
+// A set of comparable keys, optionally bounded to a maximum size.
+//
+// When bounded, adding a key to a full set evicts the least recently added or
+// seen key. keySet is not safe for concurrent use, wrap it in an Atomic.
+type keySet struct {
+	size  int
+	keys  map[interface{}]*list.Element
+	order *list.List
+}
+
+// Creates a key set holding at most `size` keys. A `size` of 0 means the set
+// is unbounded.
+func newKeySet(size int) *keySet {
+	return &keySet{
+		size:  size,
+		keys:  make(map[interface{}]*list.Element),
+		order: list.New(),
+	}
+}
+
+// Adds a key to the set, marking it as the most recently seen.
+//
+// Returns true if the key was not already in the set.
+func (set *keySet) Add(key interface{}) bool {
+	if e, ok := set.keys[key]; ok {
+		set.order.MoveToFront(e)
+		return false
+	}
+
+	set.keys[key] = set.order.PushFront(key)
+	if set.size > 0 && set.order.Len() > set.size {
+		oldest := set.order.Back()
+		set.order.Remove(oldest)
+		delete(set.keys, oldest.Value)
+	}
+	return true
+}
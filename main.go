@@ -1,25 +1,27 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
 )
 
 func fetchThing (id int) Signal {
-	return NewSignal(func (subscriber Subscriber) {
-		go func () {
-			time.Sleep(2 * time.Second)
-			subscriber.OnNext(fmt.Sprintf("thing fetched %d", id))
-			subscriber.OnCompleted()
-		}()
-	})
+	return NewSingleSignal(fmt.Sprintf("thing fetched %d", id)).Delay(2 * time.Second, NewGoroutineScheduler())
 }
 
 func WriteToResponse(s Signal, w http.ResponseWriter) {
 	done := make(chan bool, 1)
 	s.SubscribeAuto(func (c string) {
 		fmt.Fprint(w, c)
+	}, func (err error) {
+		status := http.StatusInternalServerError
+		if errors.Is(err, ErrTimeout) {
+			status = http.StatusGatewayTimeout
+		}
+		http.Error(w, err.Error(), status)
+		done <- true
 	}, func () {
 		done <- true
 	})
@@ -27,7 +29,7 @@ func WriteToResponse(s Signal, w http.ResponseWriter) {
 }
 
 func handler(w http.ResponseWriter, r *http.Request) {
-	WriteToResponse(fetchThing(2), w)
+	WriteToResponse(fetchThing(2).Timeout(5 * time.Second, NewGoroutineScheduler()), w)
 }
 
 func main() {
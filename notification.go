@@ -0,0 +1,72 @@
+package main
+
+import "fmt"
+
+// The kind of event a Notification represents.
+type NotificationKind int
+
+const (
+	NotificationNext NotificationKind = iota
+	NotificationError
+	NotificationCompleted
+)
+
+func (kind NotificationKind) String() string {
+	switch kind {
+	case NotificationNext:
+		return "Next"
+	case NotificationError:
+		return "Error"
+	case NotificationCompleted:
+		return "Completed"
+	}
+	return fmt.Sprintf("NotificationKind(%d)", int(kind))
+}
+
+// Represents a single event sent by a signal, as a value.
+//
+// `Value` is only meaningful for Next notifications, `Err` for Error ones.
+type Notification struct {
+	Kind  NotificationKind
+	Value T
+	Err   error
+}
+
+func NewNextNotification(value T) Notification {
+	return Notification{Kind: NotificationNext, Value: value}
+}
+
+func NewErrorNotification(err error) Notification {
+	return Notification{Kind: NotificationError, Err: err}
+}
+
+func NewCompletedNotification() Notification {
+	return Notification{Kind: NotificationCompleted}
+}
+
+// Sends the event represented by the notification to the given subscriber.
+func (notification Notification) Accept(subscriber Subscriber) {
+	switch notification.Kind {
+	case NotificationNext:
+		subscriber.OnNext(notification.Value)
+	case NotificationError:
+		subscriber.OnError(notification.Err)
+	case NotificationCompleted:
+		subscriber.OnCompleted()
+	}
+}
+
+// Whether the notification represents an event that terminates a signal.
+func (notification Notification) IsTerminating() bool {
+	return notification.Kind != NotificationNext
+}
+
+func (notification Notification) String() string {
+	switch notification.Kind {
+	case NotificationNext:
+		return fmt.Sprintf("Next(%v)", notification.Value)
+	case NotificationError:
+		return fmt.Sprintf("Error(%v)", notification.Err)
+	}
+	return notification.Kind.String()
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Utility functions to operate on numeric values of any integer, unsigned
+// integer or floating point type, as sent by untyped signals.
+
+func isSignedKind(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Int64
+}
+
+func isUnsignedKind(k reflect.Kind) bool {
+	return k >= reflect.Uint && k <= reflect.Uintptr
+}
+
+func isFloatKind(k reflect.Kind) bool {
+	return k == reflect.Float32 || k == reflect.Float64
+}
+
+func numericValue(v T) (reflect.Value, error) {
+	value := reflect.ValueOf(v)
+	if !value.IsValid() {
+		return value, errors.New("Expect numeric type got nil")
+	}
+	if k := value.Kind(); !isSignedKind(k) && !isUnsignedKind(k) && !isFloatKind(k) {
+		return value, errors.New(fmt.Sprintf("Expect numeric type got %v", value.Type()))
+	}
+	return value, nil
+}
+
+func toFloat64(value reflect.Value) float64 {
+	switch k := value.Kind(); {
+	case isSignedKind(k):
+		return float64(value.Int())
+	case isUnsignedKind(k):
+		return float64(value.Uint())
+	}
+	return value.Float()
+}
+
+// Adds two numeric values.
+//
+// If both values have the same type, so does the result. Otherwise the result
+// is a float64.
+func addNumbers(a T, b T) (T, error) {
+	aV, err := numericValue(a)
+	if err != nil {
+		return nil, err
+	}
+	bV, err := numericValue(b)
+	if err != nil {
+		return nil, err
+	}
+	if aV.Type() != bV.Type() {
+		return toFloat64(aV) + toFloat64(bV), nil
+	}
+
+	result := reflect.New(aV.Type()).Elem()
+	switch k := aV.Kind(); {
+	case isSignedKind(k):
+		result.SetInt(aV.Int() + bV.Int())
+	case isUnsignedKind(k):
+		result.SetUint(aV.Uint() + bV.Uint())
+	default:
+		result.SetFloat(aV.Float() + bV.Float())
+	}
+	return result.Interface(), nil
+}
+
+// Compares two numeric values, or two strings.
+//
+// Returns a negative number if `a < b`, a positive number if `a > b` and 0 if
+// they are equal.
+func compareValues(a T, b T) (int, error) {
+	if aS, ok := a.(string); ok {
+		bS, ok := b.(string)
+		if !ok {
+			return 0, errors.New(fmt.Sprintf("Expect type string got %T", b))
+		}
+		return compareOrdered(aS < bS, aS > bS), nil
+	}
+
+	aV, err := numericValue(a)
+	if err != nil {
+		return 0, err
+	}
+	bV, err := numericValue(b)
+	if err != nil {
+		return 0, err
+	}
+	aK, bK := aV.Kind(), bV.Kind()
+	switch {
+	case isSignedKind(aK) && isSignedKind(bK):
+		return compareOrdered(aV.Int() < bV.Int(), aV.Int() > bV.Int()), nil
+	case isUnsignedKind(aK) && isUnsignedKind(bK):
+		return compareOrdered(aV.Uint() < bV.Uint(), aV.Uint() > bV.Uint()), nil
+	}
+	aF, bF := toFloat64(aV), toFloat64(bV)
+	return compareOrdered(aF < bF, aF > bF), nil
+}
+
+func compareOrdered(less bool, greater bool) int {
+	if less {
+		return -1
+	}
+	if greater {
+		return 1
+	}
+	return 0
+}
+
+// Typed counterparts of Sum, Min, Max and Average, for signals whose values
+// all have the same type. They are package functions, as Go methods cannot
+// have type parameters.
+
+// The types accepted by SumOf and AverageOf.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Returns a signal which will send the sum of the values sent by `signal`,
+// as an N, when it completes. The sum of no values is 0.
+//
+// The returned signal errors if a value is not an N.
+func SumOf[N Number](signal Signal) Signal {
+	return reduceOf(signal, N(0), func(sum N, value N, _ bool) N {
+		return sum + value
+	}, func(sum N, _ int) (U, bool) {
+		return sum, true
+	})
+}
+
+// Returns a signal which will send the smallest of the values sent by
+// `signal` when it completes, or nothing if it sends no value.
+//
+// The returned signal errors if a value is not an N.
+func MinOf[N cmp.Ordered](signal Signal) Signal {
+	return reduceOf(signal, *new(N), func(current N, value N, first bool) N {
+		if first {
+			return value
+		}
+		return min(current, value)
+	}, func(current N, count int) (U, bool) {
+		return current, count > 0
+	})
+}
+
+// Returns a signal which will send the largest of the values sent by
+// `signal` when it completes, or nothing if it sends no value.
+//
+// The returned signal errors if a value is not an N.
+func MaxOf[N cmp.Ordered](signal Signal) Signal {
+	return reduceOf(signal, *new(N), func(current N, value N, first bool) N {
+		if first {
+			return value
+		}
+		return max(current, value)
+	}, func(current N, count int) (U, bool) {
+		return current, count > 0
+	})
+}
+
+// Returns a signal which will send the average of the values sent by
+// `signal`, as a float64, when it completes, or nothing if it sends no value.
+//
+// The returned signal errors if a value is not an N.
+func AverageOf[N Number](signal Signal) Signal {
+	return reduceOf(signal, 0.0, func(sum float64, value N, _ bool) float64 {
+		return sum + float64(value)
+	}, func(sum float64, count int) (U, bool) {
+		return sum / float64(count), count > 0
+	})
+}
+
+type typedReduction[A any] struct {
+	acc   A
+	count int
+}
+
+// Reduces the values of `signal`, which must all be Vs, with `f`, which is
+// told whether it is given the first value. `result` maps the final value and
+// the number of values to the value to send, if any.
+//
+// The returned signal errors as soon as a value is not a V, disposing of
+// `signal`.
+func reduceOf[V any, A any](signal Signal, initial A, f func(A, V, bool) A, result func(A, int) (U, bool)) Signal {
+	return NewSignal(func(subscriber Subscriber) {
+		reduction := NewAtomic(typedReduction[A]{acc: initial})
+		upstream := NewSubscriber(
+			func(value T) {
+				v, ok := value.(V)
+				if !ok {
+					subscriber.OnError(errors.New(fmt.Sprintf("Expect type %T got %T", *new(V), value)))
+					return
+				}
+				r := reduction.Value().(typedReduction[A])
+				reduction.SetValue(typedReduction[A]{f(r.acc, v, r.count == 0), r.count + 1})
+			},
+			func(err error) {
+				subscriber.OnError(err)
+			},
+			func() {
+				r := reduction.Value().(typedReduction[A])
+				if value, ok := result(r.acc, r.count); ok {
+					subscriber.OnNext(value)
+				}
+				subscriber.OnCompleted()
+			},
+		)
+		subscriber.Disposable().AddDisposable(upstream.Disposable())
+		signal.Subscribe(upstream)
+	})
+}
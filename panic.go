@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"runtime/debug"
+)
+
+// The error sent to subscribers when a signal, an operator function or a
+// subscriber callback panics.
+type PanicError struct {
+	// The value the function panicked with.
+	Value interface{}
+	// The stack trace of the goroutine that panicked.
+	Stack []byte
+}
+
+func (err *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", err.Value)
+}
+
+// Returns the value the function panicked with if it is an error, so that
+// `errors.Is` and `errors.As` see through PanicErrors.
+func (err *PanicError) Unwrap() error {
+	if e, ok := err.Value.(error); ok {
+		return e
+	}
+	return nil
+}
+
+var repanic = NewAtomic(false)
+
+// Sets whether panics should be left to crash the program instead of being
+// recovered and sent as PanicErrors. Useful to get the original stack trace
+// in a debugger.
+//
+// Defaults to false.
+func SetRepanic(enabled bool) {
+	repanic.SetValue(enabled)
+}
+
+func logPanic(err *PanicError) {
+	log.Printf("%v\n%s", err, err.Stack)
+}
+
+var unhandledPanicHandler = NewAtomic(logPanic)
+
+// Sets a function to be called with panics that cannot be sent to any error
+// callback: those reaching a subscriber created without one, and those
+// raised by error and completion callbacks.
+//
+// Pass nil to restore the default, which logs the panic and its stack trace.
+func SetUnhandledPanicHandler(handler func(*PanicError)) {
+	if handler == nil {
+		handler = logPanic
+	}
+	unhandledPanicHandler.SetValue(handler)
+}
+
+func reportUnhandledPanic(err *PanicError) {
+	unhandledPanicHandler.Value().(func(*PanicError))(err)
+}
+
+// Recovers from a panic, passing it to `handler` as a PanicError.
+//
+// Must be deferred directly, as `recover` only works in deferred functions.
+func recoverPanic(handler func(error)) {
+	if repanic.Value().(bool) {
+		return
+	}
+	if r := recover(); r != nil {
+		handler(&PanicError{r, debug.Stack()})
+	}
+}
+
+// Calls `f`, returning a PanicError if it panicked.
+func catchPanic(f func()) (err error) {
+	defer recoverPanic(func(p error) {
+		err = p
+	})
+	f()
+	return nil
+}
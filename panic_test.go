@@ -0,0 +1,132 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMapShouldSendPanicsAsErrors(t *testing.T) {
+	result := make([]int, 0)
+	var err error
+	NewValuesSignal([]interface{}{1, 2, 3}).Map(func(v T) U {
+		if v.(int) == 2 {
+			panic("two")
+		}
+		return v
+	}).SubscribeFunc(func(v T) {
+		result = append(result, v.(int))
+	}, func(e error) {
+		err = e
+	}, nil)
+
+	if len(result) != 1 || result[0] != 1 {
+		t.Errorf("Expect `result` to equal [1], got %v", result)
+	}
+	var panicErr *PanicError
+	if !errors.As(err, &panicErr) {
+		t.Fatalf("Expect `err` to be a PanicError, got %v", err)
+	}
+	if panicErr.Value != "two" {
+		t.Errorf("Expect `panicErr.Value` to equal two, got %v", panicErr.Value)
+	}
+	if len(panicErr.Stack) == 0 {
+		t.Error("Expect `panicErr.Stack` not to be empty")
+	}
+}
+
+func TestSubscriberShouldSendPanicsToItself(t *testing.T) {
+	failure := errors.New("failed")
+	disposed := false
+	var err error
+	NewSignal(func(subscriber Subscriber) {
+		subscriber.Disposable().AddDisposableFunc(func() error {
+			disposed = true
+			return nil
+		})
+		subscriber.OnNext(1)
+		subscriber.OnNext(2)
+	}).SubscribeFunc(func(_ T) {
+		panic(failure)
+	}, func(e error) {
+		err = e
+	}, nil)
+
+	if !errors.Is(err, failure) {
+		t.Errorf("Expect `err` to wrap %v, got %v", failure, err)
+	}
+	if disposed != true {
+		t.Error("Expect the signal to be disposed")
+	}
+}
+
+func TestSubscribeShouldSendPanicsAsErrors(t *testing.T) {
+	var err error
+	NewSignal(func(_ Subscriber) {
+		panic("failed")
+	}).SubscribeFunc(nil, func(e error) {
+		err = e
+	}, nil)
+
+	if _, ok := err.(*PanicError); !ok {
+		t.Errorf("Expect `err` to be a PanicError, got %v", err)
+	}
+}
+
+func TestCatchShouldSendPanicsAsErrors(t *testing.T) {
+	var err error
+	NewErrorSignal(errors.New("failed")).Catch(func(_ error) Signal {
+		panic("handler")
+	}).SubscribeFunc(nil, func(e error) {
+		err = e
+	}, nil)
+
+	if _, ok := err.(*PanicError); !ok {
+		t.Errorf("Expect `err` to be a PanicError, got %v", err)
+	}
+}
+
+func TestSetRepanicShouldLetPanicsThrough(t *testing.T) {
+	SetRepanic(true)
+	defer SetRepanic(false)
+
+	defer func() {
+		if r := recover(); r != "failed" {
+			t.Errorf("Expect to recover failed, got %v", r)
+		}
+	}()
+	NewSingleSignal(1).SubscribeFunc(func(_ T) {
+		panic("failed")
+	}, nil, nil)
+	t.Error("Expect SubscribeFunc to panic")
+}
+
+func TestPanicsWithoutErrorCallbackShouldBeReported(t *testing.T) {
+	reported := make([]interface{}, 0)
+	SetUnhandledPanicHandler(func(err *PanicError) {
+		reported = append(reported, err.Value)
+	})
+	defer SetUnhandledPanicHandler(nil)
+
+	NewValuesSignal([]interface{}{1, 2}).SubscribeAuto(func(v int) {
+		panic("next")
+	})
+	NewValuesSignal([]interface{}{1, 2}).Map(func(v T) U {
+		panic("map")
+	}).SubscribeAuto(func(v int) {})
+	NewEmptySignal().SubscribeAuto(func() {
+		panic("completed")
+	})
+	NewErrorSignal(errors.New("failed")).SubscribeAuto(func(_ error) {
+		panic("error")
+	})
+
+	expected := []interface{}{"next", "map", "completed", "error"}
+	if len(reported) != len(expected) {
+		t.Fatalf("Expecting `len(reported)` to equal %v got %v", len(expected), len(reported))
+	}
+	for i, v := range expected {
+		if v != reported[i] {
+			t.Errorf("Expect `reported[%d]` to equal %v, got %v", i, v, reported[i])
+		}
+	}
+}
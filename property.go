@@ -0,0 +1,81 @@
+package main
+
+// Represents a value that changes over time, and can be observed.
+type Property interface {
+	Value() T
+	Producer() Signal
+	Signal() Signal
+}
+
+// A property whose value can be set directly, or driven by a signal.
+type MutableProperty interface {
+	Property
+	SetValue(T)
+	BindTo(Signal) Disposable
+}
+
+type mutableProperty struct {
+	subject BehaviorSubject
+}
+
+// Creates a mutable property holding `initial`.
+func NewMutableProperty(initial T) MutableProperty {
+	return &mutableProperty{NewBehaviorSubject(initial)}
+}
+
+// The current value of the property.
+func (property *mutableProperty) Value() T {
+	return property.subject.Value()
+}
+
+// Sets the value of the property, sending it to the property's observers.
+//
+// Values set concurrently from several goroutines may be observed in a
+// different order than the one they were stored in.
+func (property *mutableProperty) SetValue(value T) {
+	property.subject.OnNext(value)
+}
+
+// A signal that will send the property's current value, followed by all
+// changes over time.
+func (property *mutableProperty) Producer() Signal {
+	return property.subject
+}
+
+// A signal that will send all future changes to the property's value,
+// without sending the current value.
+func (property *mutableProperty) Signal() Signal {
+	return property.subject.Skip(1)
+}
+
+// Sets the property's value to every value sent by `signal`.
+//
+// Returns a Disposable which will stop the binding.
+func (property *mutableProperty) BindTo(signal Signal) Disposable {
+	return signal.SubscribeFunc(property.SetValue, nil, nil)
+}
+
+// A read-only view of another property, whose values are mapped with a
+// function.
+type mappedProperty struct {
+	property Property
+	f        func(T) U
+}
+
+// Creates a read-only property whose value is always `f` applied to the
+// value of `property`.
+func NewMappedProperty(property Property, f func(T) U) Property {
+	return &mappedProperty{property, f}
+}
+
+func (property *mappedProperty) Value() T {
+	return property.f(property.property.Value())
+}
+
+func (property *mappedProperty) Producer() Signal {
+	return property.property.Producer().Map(property.f)
+}
+
+func (property *mappedProperty) Signal() Signal {
+	return property.property.Signal().Map(property.f)
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestMutablePropertyShouldStoreValue(t *testing.T) {
+	property := NewMutableProperty(1)
+	if property.Value() != 1 {
+		t.Errorf("Expect `property.Value()` to equal 1, got %v", property.Value())
+	}
+
+	property.SetValue(2)
+	if property.Value() != 2 {
+		t.Errorf("Expect `property.Value()` to equal 2, got %v", property.Value())
+	}
+}
+
+func TestMutablePropertyProducerShouldSendCurrentValueThenChanges(t *testing.T) {
+	property := NewMutableProperty(1)
+	values := make([]int, 0)
+	changes := make([]int, 0)
+
+	property.Producer().SubscribeAuto(func(v int) {
+		values = append(values, v)
+	})
+	property.Signal().SubscribeAuto(func(v int) {
+		changes = append(changes, v)
+	})
+	property.SetValue(2)
+	property.SetValue(3)
+
+	if len(values) != 3 || values[0] != 1 || values[2] != 3 {
+		t.Errorf("Expect `values` to equal [1 2 3], got %v", values)
+	}
+	if len(changes) != 2 || changes[0] != 2 || changes[1] != 3 {
+		t.Errorf("Expect `changes` to equal [2 3], got %v", changes)
+	}
+}
+
+func TestMutablePropertyShouldBindToSignal(t *testing.T) {
+	property := NewMutableProperty(0)
+	var source Subscriber
+
+	disposable := property.BindTo(NewSignal(func(s Subscriber) {
+		source = s
+	}))
+	source.OnNext(1)
+	if property.Value() != 1 {
+		t.Errorf("Expect `property.Value()` to equal 1, got %v", property.Value())
+	}
+
+	disposable.Dispose()
+	source.OnNext(2)
+	if property.Value() != 1 {
+		t.Errorf("Expect `property.Value()` to equal 1 after disposal, got %v", property.Value())
+	}
+}
+
+func TestMappedPropertyShouldMapValues(t *testing.T) {
+	property := NewMutableProperty(1)
+	mapped := NewMappedProperty(property, func(v T) U {
+		return v.(int) * 10
+	})
+	values := make([]int, 0)
+
+	mapped.Producer().SubscribeAuto(func(v int) {
+		values = append(values, v)
+	})
+	property.SetValue(2)
+
+	if mapped.Value() != 20 {
+		t.Errorf("Expect `mapped.Value()` to equal 20, got %v", mapped.Value())
+	}
+	if len(values) != 2 || values[0] != 10 || values[1] != 20 {
+		t.Errorf("Expect `values` to equal [10 20], got %v", values)
+	}
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"sort"
+	"time"
+)
+
+// Represents a context in which work can be performed, either immediately or
+// at a later time.
+type Scheduler interface {
+	Schedule(func()) Disposable
+	ScheduleAfter(time.Duration, func()) Disposable
+	Now() time.Time
+}
+
+// A scheduler that performs each action on its own goroutine, using the wall
+// clock for delayed actions.
+type goroutineScheduler struct{}
+
+// Creates a scheduler that will run each action on a new goroutine.
+//
+// Actions are not serialized: two actions scheduled on the same scheduler may
+// run concurrently.
+func NewGoroutineScheduler() Scheduler {
+	return &goroutineScheduler{}
+}
+
+// Enqueues an action on a new goroutine.
+//
+// Returns a Disposable which will prevent the action from running if it has
+// not started yet.
+func (scheduler *goroutineScheduler) Schedule(action func()) Disposable {
+	disposable := NewSimpleDisposable()
+	go func() {
+		if disposable.IsDisposed() == false {
+			action()
+		}
+	}()
+	return disposable
+}
+
+// Enqueues an action to be run after the given delay.
+//
+// Returns a Disposable which will cancel the pending action.
+func (scheduler *goroutineScheduler) ScheduleAfter(delay time.Duration, action func()) Disposable {
+	disposable := NewSimpleDisposable()
+	timer := time.AfterFunc(delay, func() {
+		if disposable.IsDisposed() == false {
+			action()
+		}
+	})
+	return NewActionDisposable(func() error {
+		timer.Stop()
+		return disposable.Dispose()
+	})
+}
+
+func (scheduler *goroutineScheduler) Now() time.Time {
+	return time.Now()
+}
+
+// A scheduler that runs actions on a virtual clock, which only moves forward
+// when explicitly advanced.
+//
+// Useful to deterministically test time-based operators.
+type TestScheduler struct {
+	state Atomic
+}
+
+type scheduledAction struct {
+	due        time.Time
+	action     func()
+	disposable Disposable
+}
+
+type testSchedulerState struct {
+	now     time.Time
+	actions []scheduledAction
+}
+
+// Creates a test scheduler whose virtual clock starts at `start`.
+func NewTestScheduler(start time.Time) *TestScheduler {
+	return &TestScheduler{NewAtomic(testSchedulerState{now: start})}
+}
+
+// Enqueues an action to be run at the current virtual time, the next time
+// the scheduler is advanced.
+func (scheduler *TestScheduler) Schedule(action func()) Disposable {
+	return scheduler.ScheduleAfter(0, action)
+}
+
+// Enqueues an action to be run once the virtual clock has moved forward by
+// `delay`.
+func (scheduler *TestScheduler) ScheduleAfter(delay time.Duration, action func()) Disposable {
+	disposable := NewSimpleDisposable()
+	scheduler.state.Modify(func(s interface{}) interface{} {
+		state := s.(testSchedulerState)
+		actions := append(make([]scheduledAction, 0, len(state.actions)+1), state.actions...)
+		actions = append(actions, scheduledAction{state.now.Add(delay), action, disposable})
+		sort.SliceStable(actions, func(i, j int) bool {
+			return actions[i].due.Before(actions[j].due)
+		})
+		return testSchedulerState{state.now, actions}
+	})
+	return disposable
+}
+
+// The current virtual time.
+func (scheduler *TestScheduler) Now() time.Time {
+	return scheduler.state.Value().(testSchedulerState).now
+}
+
+// Moves the virtual clock forward by `interval`, running every action due
+// until then in order.
+//
+// Actions scheduled while advancing are run as well if they fall within
+// `interval`.
+func (scheduler *TestScheduler) Advance(interval time.Duration) {
+	scheduler.AdvanceTo(scheduler.Now().Add(interval))
+}
+
+// Moves the virtual clock forward to `date`, running every action due until
+// then in order.
+func (scheduler *TestScheduler) AdvanceTo(date time.Time) {
+	for {
+		_, next := scheduler.state.ModifyData(func(s interface{}) (interface{}, interface{}) {
+			state := s.(testSchedulerState)
+			if len(state.actions) == 0 || state.actions[0].due.After(date) {
+				return testSchedulerState{date, state.actions}, nil
+			}
+			return testSchedulerState{state.actions[0].due, state.actions[1:]}, state.actions[0]
+		})
+		if next == nil {
+			return
+		}
+		if a := next.(scheduledAction); a.disposable.IsDisposed() == false {
+			a.action()
+		}
+	}
+}
+
+// Runs every pending action, advancing the virtual clock as far as needed.
+func (scheduler *TestScheduler) Run() {
+	for {
+		state := scheduler.state.Value().(testSchedulerState)
+		if len(state.actions) == 0 {
+			return
+		}
+		scheduler.AdvanceTo(state.actions[len(state.actions)-1].due)
+	}
+}
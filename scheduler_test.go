@@ -0,0 +1,72 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTestSchedulerShouldRunActionsInOrderWhenAdvanced(t *testing.T) {
+	start := time.Now()
+	scheduler := NewTestScheduler(start)
+	result := make([]int, 0)
+	expected := []int{1, 2, 3}
+
+	scheduler.ScheduleAfter(2*time.Second, func() {
+		result = append(result, 3)
+	})
+	scheduler.ScheduleAfter(time.Second, func() {
+		result = append(result, 2)
+	})
+	scheduler.Schedule(func() {
+		result = append(result, 1)
+	})
+
+	if len(result) != 0 {
+		t.Fatalf("Expecting `len(result)` to equal 0 got %v", len(result))
+	}
+
+	scheduler.Advance(time.Second)
+	if len(result) != 2 {
+		t.Fatalf("Expecting `len(result)` to equal 2 got %v", len(result))
+	}
+	if scheduler.Now() != start.Add(time.Second) {
+		t.Errorf("Expect `scheduler.Now()` to equal %v, got %v", start.Add(time.Second), scheduler.Now())
+	}
+
+	scheduler.Run()
+	for i, v := range expected {
+		if v != result[i] {
+			t.Fatalf("Expecting %v to equal %v", result, expected)
+		}
+	}
+}
+
+func TestTestSchedulerShouldNotRunDisposedActions(t *testing.T) {
+	scheduler := NewTestScheduler(time.Now())
+	didRun := false
+
+	disposable := scheduler.ScheduleAfter(time.Second, func() {
+		didRun = true
+	})
+	disposable.Dispose()
+	scheduler.Run()
+
+	if didRun != false {
+		t.Error("Expect `didRun` to be false")
+	}
+}
+
+func TestGoroutineSchedulerShouldRunDelayedAction(t *testing.T) {
+	scheduler := NewGoroutineScheduler()
+	done := make(chan bool, 1)
+
+	scheduler.ScheduleAfter(time.Millisecond, func() {
+		done <- true
+	})
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Error("Expect action to run")
+	}
+}
@@ -3,12 +3,22 @@ package main
 import (
 	"errors"
 	"fmt"
+	"math/rand"
 	"reflect"
+	"time"
 )
 
 type T interface{}
 type U interface{}
 
+// The error sent by signals returned from Timeout and TimeoutTotal when no
+// event arrived in time.
+var ErrTimeout = errors.New("signal timed out")
+
+// The error sent by signals returned from First, Last and ElementAt when the
+// receiver completed without sending the requested value.
+var ErrNoElements = errors.New("signal completed without elements")
+
 // A stream that will begin generating events when a Subscriber is attached,
 // possibly performing some side effects in the process. Events are pushed to
 // the subscriber as they are generated.
@@ -32,15 +42,89 @@ type Signal interface {
 	Reduce(U, func(U, T) U) Signal
 	ReduceAuto(U, interface{}) Signal
 
+	Count() Signal
+	Sum() Signal
+	Min() Signal
+	Max() Signal
+	Average() Signal
+
+	All(func(T) bool) Signal
+	AllAuto(interface{}) Signal
+	Any(func(T) bool) Signal
+	AnyAuto(interface{}) Signal
+	Contains(T) Signal
+	IsEmpty() Signal
+	SequenceEqual(Signal, func(T, T) bool) Signal
+
 	Take(int) Signal
 	TakeLast(int) Signal
 	TakeWhile(func(T) bool) Signal
 	TakeWhileAuto(interface{}) Signal
+	TakeUntil(Signal) Signal
+
+	First() Signal
+	FirstOrDefault(T) Signal
+	Last() Signal
+	LastOrDefault(T) Signal
+	ElementAt(int) Signal
+	DefaultIfEmpty(T) Signal
+	SwitchIfEmpty(Signal) Signal
+
+	Skip(int) Signal
+	SkipLast(int) Signal
+	SkipWhile(func(T) bool) Signal
+	SkipWhileAuto(interface{}) Signal
+	SkipUntil(Signal) Signal
+
+	DistinctUntilChanged(func(T, T) bool) Signal
+	DistinctUntilChangedAuto(interface{}) Signal
+	Distinct(func(T) interface{}) Signal
+	DistinctAuto(interface{}) Signal
+	DistinctBounded(int, func(T) interface{}) Signal
+	DistinctBoundedAuto(int, interface{}) Signal
+
+	Retry(int) Signal
+	RetryWhen(func(Signal) Signal) Signal
+
+	Catch(func(error) Signal) Signal
+	CatchAuto(interface{}) Signal
+	OnErrorReturn(func(error) T) Signal
+	OnErrorReturnAuto(interface{}) Signal
+	OnErrorResumeNext(Signal) Signal
+
+	Timeout(time.Duration, Scheduler) Signal
+	TimeoutTotal(time.Duration, Scheduler) Signal
+	TimeoutWith(time.Duration, Signal, Scheduler) Signal
+
+	Delay(time.Duration, Scheduler) Signal
+	DelayAll(time.Duration, Scheduler) Signal
+	DelaySubscription(time.Duration, Scheduler) Signal
+
+	Do(func(T), func(error), func()) Signal
+	OnSubscribe(func()) Signal
+	OnDispose(func()) Signal
+	Finally(func()) Signal
+
+	Materialize() Signal
+	Dematerialize() Signal
+
+	Publish() ConnectableSignal
+	Share() Signal
+	Replay(int, time.Duration, Scheduler) ConnectableSignal
+	Cache() CachedSignal
+
+	OnBackpressureBuffer(int, OverflowStrategy) BackpressureSignal
+	OnBackpressureDrop() BackpressureSignal
+	OnBackpressureLatest() BackpressureSignal
+
+	Serialize() Signal
 
 	Merge() Signal
 
 	Concat() Signal
 	ConcatWith(s Signal) Signal
+	StartWith(...interface{}) Signal
+	EndWith(...interface{}) Signal
 }
 
 type signal struct {
@@ -93,12 +177,92 @@ func NewValuesSignal(values []interface{}) Signal {
 	}}
 }
 
+// Creates a signal that will send the scheduler's current time after
+// `delay`, then complete.
+func NewTimerSignal(delay time.Duration, scheduler Scheduler) Signal {
+	return &signal{func(subscriber Subscriber) {
+		subscriber.Disposable().AddDisposable(scheduler.ScheduleAfter(delay, func() {
+			subscriber.OnNext(scheduler.Now())
+			subscriber.OnCompleted()
+		}))
+	}}
+}
+
+// Creates a signal that will subscribe to all the given signals, then
+// forward events from whichever sends an event first, disposing of the
+// others.
+//
+// If no signal is given, the returned signal completes immediately.
+func Race(signals ...Signal) Signal {
+	if len(signals) == 0 {
+		return NewEmptySignal()
+	}
+
+	return NewSignal(func(subscriber Subscriber) {
+		winner := NewAtomic(-1)
+		disposables := make([]SerialDisposable, len(signals))
+		for i := range signals {
+			disposables[i] = NewSerialDisposable(nil)
+			subscriber.Disposable().AddDisposable(disposables[i])
+		}
+
+		// Reports whether events from the i-th signal should be forwarded,
+		// disposing of the other signals the first time it wins.
+		wins := func(i int) bool {
+			orig := winner.Modify(func(w interface{}) interface{} {
+				if w.(int) < 0 {
+					return i
+				}
+				return w
+			})
+			if orig.(int) < 0 {
+				for j, d := range disposables {
+					if j != i {
+						d.Dispose()
+					}
+				}
+				return true
+			}
+			return orig.(int) == i
+		}
+
+		for i, s := range signals {
+			if winner.Value().(int) >= 0 {
+				break
+			}
+			i := i
+			disposables[i].SetInnerDisposable(s.SubscribeFunc(
+				func(value T) {
+					if wins(i) {
+						subscriber.OnNext(value)
+					}
+				},
+				func(err error) {
+					if wins(i) {
+						subscriber.OnError(err)
+					}
+				},
+				func() {
+					if wins(i) {
+						subscriber.OnCompleted()
+					}
+				},
+			))
+		}
+	})
+}
+
 // Starts producing events for the given subscriber.
 //
 // Returns a Disposable which will cancel the work associated with event
 // production, and prevent any further events from being sent.
+//
+// If the signal panics while subscribing, the subscriber receives a
+// PanicError.
 func (signal *signal) Subscribe(subscriber Subscriber) Disposable {
-	signal.didSubscribe(subscriber)
+	if err := catchPanic(func() { signal.didSubscribe(subscriber) }); err != nil {
+		subscriber.OnError(err)
+	}
 	return subscriber.Disposable()
 }
 
@@ -217,8 +381,7 @@ func (signal *signal) ScanAuto(initial U, p interface{}) Signal {
 // Returns a signal which will send the single, aggregated value when
 // the receiver completes.
 func (signal *signal) Reduce(initial U, f func(U, T) U) Signal {
-	scanned := signal.Scan(initial, f)
-	return NewSingleSignal(initial).ConcatWith(scanned).TakeLast(1)
+	return signal.Scan(initial, f).StartWith(initial).TakeLast(1)
 }
 
 func (signal *signal) ReduceAuto(initial U, p interface{}) Signal {
@@ -229,6 +392,285 @@ func (signal *signal) ReduceAuto(initial U, p interface{}) Signal {
 	return signal.Reduce(initial, f.(func(U, T) U))
 }
 
+// Returns a signal which will send the number of values sent by the
+// receiver when it completes.
+func (signal *signal) Count() Signal {
+	return signal.Reduce(0, func(count U, _ T) U {
+		return count.(int) + 1
+	})
+}
+
+// Returns a signal which will send the sum of the numeric values sent by the
+// receiver when it completes.
+//
+// Values may be of any integer or floating point type. If they all have the
+// same type, so does the sum, otherwise it is a float64. The sum of no values
+// is the int 0. The returned signal errors if a value is not numeric.
+func (signal *signal) Sum() Signal {
+	return signal.reduceNumbers(func(sum U, value T) (U, error) {
+		if sum == nil {
+			_, err := numericValue(value)
+			return value, err
+		}
+		return addNumbers(sum, value)
+	}, func(sum U) U {
+		if sum == nil {
+			return 0
+		}
+		return sum
+	})
+}
+
+// Returns a signal which will send the smallest of the values sent by the
+// receiver when it completes.
+//
+// Values may be of any integer or floating point type, or strings. If the
+// receiver sends no value, the returned signal completes without sending
+// any. The returned signal errors if values cannot be compared.
+func (signal *signal) Min() Signal {
+	return signal.reduceNumbers(func(min U, value T) (U, error) {
+		return extremum(min, value, -1)
+	}, nil)
+}
+
+// Returns a signal which will send the largest of the values sent by the
+// receiver when it completes.
+//
+// See Min for the accepted value types.
+func (signal *signal) Max() Signal {
+	return signal.reduceNumbers(func(max U, value T) (U, error) {
+		return extremum(max, value, 1)
+	}, nil)
+}
+
+// Returns a signal which will send the average of the numeric values sent by
+// the receiver, as a float64, when it completes.
+//
+// If the receiver sends no value, the returned signal completes without
+// sending any. The returned signal errors if a value is not numeric.
+func (signal *signal) Average() Signal {
+	type average struct {
+		sum   float64
+		count int
+	}
+
+	return signal.reduceNumbers(func(a U, value T) (U, error) {
+		v, err := numericValue(value)
+		if err != nil {
+			return nil, err
+		}
+		if a == nil {
+			return average{toFloat64(v), 1}, nil
+		}
+		return average{a.(average).sum + toFloat64(v), a.(average).count + 1}, nil
+	}, func(a U) U {
+		if a == nil {
+			return nil
+		}
+		return a.(average).sum / float64(a.(average).count)
+	})
+}
+
+// Returns whichever of `current` and `value` compares as `sign` to the
+// other, `current` being nil when there is no value yet.
+func extremum(current U, value T, sign int) (U, error) {
+	if current == nil {
+		_, err := compareValues(value, value)
+		return value, err
+	}
+	c, err := compareValues(value, current)
+	if err != nil {
+		return nil, err
+	}
+	if c*sign > 0 {
+		return value, nil
+	}
+	return current, nil
+}
+
+// Reduces the values of the receiver, starting from nil, with a function
+// that may fail. The first error is sent right away by the returned signal,
+// disposing of the receiver.
+//
+// If given, `result` maps the final value before sending it. A nil final
+// value is not sent.
+func (signal *signal) reduceNumbers(f func(U, T) (U, error), result func(U) U) Signal {
+	return NewSignal(func(subscriber Subscriber) {
+		acc := NewAtomic(nil)
+		upstream := NewSubscriber(
+			func(value T) {
+				next, err := f(acc.Value(), value)
+				if err != nil {
+					subscriber.OnError(err)
+					return
+				}
+				acc.SetValue(next)
+			},
+			func(err error) {
+				subscriber.OnError(err)
+			},
+			func() {
+				value := acc.Value()
+				if result != nil {
+					value = result(value)
+				}
+				if value != nil {
+					subscriber.OnNext(value)
+				}
+				subscriber.OnCompleted()
+			},
+		)
+		// Added before subscribing, so that an error stops synchronous
+		// signals right away.
+		subscriber.Disposable().AddDisposable(upstream.Disposable())
+		signal.Subscribe(upstream)
+	})
+}
+
+// Returns a signal which will send whether all the values sent by the
+// receiver pass `predicate`.
+//
+// The receiver is disposed of as soon as a value fails the predicate.
+func (signal *signal) All(predicate func(T) bool) Signal {
+	return signal.decide(func(value T) bool {
+		return !predicate(value)
+	}, false, true)
+}
+
+func (signal *signal) AllAuto(p interface{}) Signal {
+	f, err := castFunc(p, (func(T) bool)(nil))
+	if err != nil {
+		panic(err)
+	}
+	return signal.All(f.(func(T) bool))
+}
+
+// Returns a signal which will send whether any of the values sent by the
+// receiver passes `predicate`.
+//
+// The receiver is disposed of as soon as a value passes the predicate.
+func (signal *signal) Any(predicate func(T) bool) Signal {
+	return signal.decide(predicate, true, false)
+}
+
+func (signal *signal) AnyAuto(p interface{}) Signal {
+	f, err := castFunc(p, (func(T) bool)(nil))
+	if err != nil {
+		panic(err)
+	}
+	return signal.Any(f.(func(T) bool))
+}
+
+// Returns a signal which will send whether the receiver sends a value equal
+// to `value`.
+func (signal *signal) Contains(value T) Signal {
+	return signal.Any(func(v T) bool {
+		return v == value
+	})
+}
+
+// Returns a signal which will send whether the receiver completes without
+// sending any value.
+func (signal *signal) IsEmpty() Signal {
+	return signal.decide(func(_ T) bool {
+		return true
+	}, false, true)
+}
+
+// Sends `decided` as soon as a value of the receiver passes `f`, disposing
+// of the receiver, or `otherwise` if the receiver completes before that.
+func (signal *signal) decide(f func(T) bool, decided T, otherwise T) Signal {
+	return signal.mapAccumulate(true, func(_ interface{}, value T) (interface{}, U) {
+		if f(value) {
+			return nil, NewSingleSignal(decided)
+		}
+		return true, NewEmptySignal()
+	}).Merge().EndWith(otherwise).Take(1)
+}
+
+// Returns a signal which will send whether the receiver and `other` send
+// the same values in the same order, then complete.
+//
+// If `equal` is nil, values are compared with `==`. Both signals are disposed
+// of as soon as they are known to differ.
+func (signal *signal) SequenceEqual(other Signal, equal func(T, T) bool) Signal {
+	if equal == nil {
+		equal = func(a, b T) bool {
+			return a == b
+		}
+	}
+
+	type sequence struct {
+		pending   []T
+		completed bool
+	}
+
+	return NewSignal(func(subscriber Subscriber) {
+		subscriber = NewSerializedSubscriber(subscriber)
+		// Only one of the two sequences has pending values at any time, as
+		// they are compared as soon as both sides have one.
+		state := NewAtomic([2]sequence{})
+		sendResult := func(equal bool) {
+			subscriber.OnNext(equal)
+			subscriber.OnCompleted()
+		}
+
+		subscribeTo := func(s Signal, self int) {
+			other := 1 - self
+			sequenceSubscriber := NewSubscriber(
+				func(value T) {
+					_, result := state.ModifyData(func(st interface{}) (interface{}, interface{}) {
+						sequences := st.([2]sequence)
+						if len(sequences[other].pending) == 0 {
+							if sequences[other].completed {
+								return sequences, false
+							}
+							sequences[self].pending = append(sequences[self].pending, value)
+							return sequences, nil
+						}
+						if !equal(sequences[other].pending[0], value) {
+							return sequences, false
+						}
+						sequences[other].pending = sequences[other].pending[1:]
+						return sequences, nil
+					})
+					if result != nil {
+						sendResult(result.(bool))
+					}
+				},
+				func(err error) {
+					subscriber.OnError(err)
+				},
+				func() {
+					_, result := state.ModifyData(func(st interface{}) (interface{}, interface{}) {
+						sequences := st.([2]sequence)
+						sequences[self].completed = true
+						if len(sequences[other].pending) > 0 {
+							return sequences, false
+						}
+						if sequences[other].completed {
+							return sequences, len(sequences[self].pending) == 0
+						}
+						return sequences, nil
+					})
+					if result != nil {
+						sendResult(result.(bool))
+					}
+				},
+			)
+			subscriber.Disposable().AddDisposable(sequenceSubscriber.Disposable())
+			s.Subscribe(sequenceSubscriber)
+		}
+
+		subscribeTo(signal, 0)
+		// The receiver may have decided the result synchronously, in which
+		// case `other` is not subscribed to at all.
+		if subscriber.Disposable().IsDisposed() == false {
+			subscribeTo(other, 1)
+		}
+	})
+}
+
 /// Returns a signal that will yield the first `count` values from the
 /// receiver.
 func (signal *signal) Take(count int) Signal {
@@ -311,162 +753,1152 @@ func (signal *signal) TakeWhileAuto(p interface{}) Signal {
 	return signal.TakeWhile(f.(func(T) bool))
 }
 
-// Merges a signal of signals down into a single signal, biased toward the
-// signals added earlier.
+// Returns a signal that will forward values from the receiver until
+// `trigger` sends a value or completes.
 //
-// Returns a signal that will forward events from the original signals
-// as they arrive.
-func (signal *signal) Merge() Signal {
+// At that point the returned signal completes and the receiver is disposed
+// of.
+func (signal *signal) TakeUntil(trigger Signal) Signal {
 	return NewSignal(func(subscriber Subscriber) {
-		disposable := NewCompositeDisposable(nil)
-		inFlight := NewAtomic(1)
-
-		decrementInFlight := func() {
-			orig := inFlight.Modify(func(v interface{}) interface{} {
-				return v.(int) - 1
-			})
-			if orig == 1 {
+		subscriber = NewSerializedSubscriber(subscriber)
+		triggerDisposable := trigger.SubscribeFunc(
+			func(_ T) {
 				subscriber.OnCompleted()
-			}
-		}
-
-		selfDisposable := signal.SubscribeFunc(
-			func(stream T) {
-				inFlight.Modify(func(v interface{}) interface{} {
-					return v.(int) + 1
-				})
-
-				streamDisposable := NewSerialDisposable(nil)
-				disposable.AddDisposable(streamDisposable)
-
-				streamDisposable.SetInnerDisposable(stream.(Signal).SubscribeFunc(
-					func(value T) {
-						subscriber.OnNext(value.(interface{}))
-					},
-					func(err error) {
-						streamDisposable.Dispose()
-						disposable.PruneDisposed()
-						subscriber.OnError(err)
-					},
-					func() {
-						streamDisposable.Dispose()
-						disposable.PruneDisposed()
-						decrementInFlight()
-					},
-				))
 			},
 			func(err error) {
 				subscriber.OnError(err)
 			},
 			func() {
-				decrementInFlight()
+				subscriber.OnCompleted()
 			},
 		)
+		subscriber.Disposable().AddDisposable(triggerDisposable)
 
-		subscriber.Disposable().AddDisposable(selfDisposable)
-	})
-}
-
-// Concatenates each inner signal with the previous and next inner signals.
-//
-// Returns a signal that will forward events from each of the original
-// signals, in sequential order.
-func (signal *signal) Concat() Signal {
-	return NewSignal(func(subscriber Subscriber) {
-		// TODO fix implementation to not rely on channel size
-		// also check if this all makes sense
-		concatChan := make(chan Signal, 10)
-		var currentSignal Signal
-		var subscribeToNextSignal func()
-		subscribeToNextSignal = func() {
-			if currentSignal != nil {
-				return
-			}
-			select {
-			case currentSignal, more := <-concatChan:
-				if !more {
-					subscriber.OnCompleted()
-					return
-				}
-				signalDisposable := currentSignal.SubscribeFunc(
-					func(v T) {
-						subscriber.OnNext(v)
-					},
-					func(err error) {
-						subscriber.OnError(err)
-					},
-					func() {
-						subscribeToNextSignal()
-					},
-				)
-				subscriber.Disposable().AddDisposable(signalDisposable)
-			default:
-			}
+		if subscriber.Disposable().IsDisposed() {
+			return
 		}
 
-		selfDisposable := signal.SubscribeFunc(
-			func(signal T) {
-				concatChan <- signal.(Signal)
-				subscribeToNextSignal()
+		disposable := signal.SubscribeFunc(
+			func(value T) {
+				subscriber.OnNext(value)
 			},
 			func(err error) {
 				subscriber.OnError(err)
 			},
 			func() {
-				close(concatChan)
+				subscriber.OnCompleted()
 			},
 		)
-
-		subscriber.Disposable().AddDisposable(selfDisposable)
+		subscriber.Disposable().AddDisposable(disposable)
 	})
 }
 
-/// Concatenates the given signal after the receiver.
-func (signal *signal) ConcatWith(s Signal) Signal {
-	return NewValuesSignal([]interface{}{signal, s}).Concat()
+// Returns a signal that will send the first value of the receiver, or error
+// with ErrNoElements if the receiver completes without sending any value.
+func (signal *signal) First() Signal {
+	return signal.Take(1).SwitchIfEmpty(NewErrorSignal(ErrNoElements))
 }
 
-// Maps over the elements of the signal, accumulating a state along the
-// way.
-//
-// This is meant as a primitive operator from which more complex operators
-// can be built.
-//
-// Yielding a `nil` state at any point will stop evaluation of the original
-// signal, and dispose of it.
-//
-// Returns a signal of the mapped values.
-func (signal *signal) mapAccumulate(initialState interface{}, f func(state interface{}, current T) (newState interface{}, newValue U)) Signal {
+// Returns a signal that will send the first value of the receiver, or
+// `value` if the receiver completes without sending any value.
+func (signal *signal) FirstOrDefault(value T) Signal {
+	return signal.Take(1).DefaultIfEmpty(value)
+}
+
+// Returns a signal that will send the last value of the receiver once it
+// completes, or error with ErrNoElements if it did not send any value.
+func (signal *signal) Last() Signal {
+	return signal.TakeLast(1).SwitchIfEmpty(NewErrorSignal(ErrNoElements))
+}
+
+// Returns a signal that will send the last value of the receiver once it
+// completes, or `value` if it did not send any value.
+func (signal *signal) LastOrDefault(value T) Signal {
+	return signal.TakeLast(1).DefaultIfEmpty(value)
+}
+
+// Returns a signal that will send the value at `index` in the receiver, or
+// error with ErrNoElements if the receiver completes before sending it.
+func (signal *signal) ElementAt(index int) Signal {
+	if index < 0 {
+		panic("Signal.ElementAt: index parameter should be >= 0")
+	}
+	return signal.Skip(index).First()
+}
+
+// Returns a signal that will forward events from the receiver, or send
+// `value` if the receiver completes without sending any value.
+func (signal *signal) DefaultIfEmpty(value T) Signal {
+	return signal.SwitchIfEmpty(NewSingleSignal(value))
+}
+
+// Returns a signal that will forward events from the receiver, or from
+// `other` if the receiver completes without sending any value.
+func (signal *signal) SwitchIfEmpty(other Signal) Signal {
 	return NewSignal(func(subscriber Subscriber) {
-		state := NewAtomic(initialState)
+		sent := NewAtomic(false)
 		disposable := signal.SubscribeFunc(
-			// Next
 			func(value T) {
-				newState, newValue := f(state.Value(), value)
-				subscriber.OnNext(newValue)
-
-				if newState != nil {
-					state.SetValue(newState)
-				} else {
-					subscriber.OnCompleted()
-				}
+				sent.SetValue(true)
+				subscriber.OnNext(value)
 			},
-			// Error
 			func(err error) {
 				subscriber.OnError(err)
 			},
-			// Completed
 			func() {
-				subscriber.OnCompleted()
+				if sent.Value().(bool) {
+					subscriber.OnCompleted()
+					return
+				}
+				subscriber.Disposable().AddDisposable(other.SubscribeFunc(
+					func(value T) {
+						subscriber.OnNext(value)
+					},
+					func(err error) {
+						subscriber.OnError(err)
+					},
+					func() {
+						subscriber.OnCompleted()
+					},
+				))
 			},
 		)
 		subscriber.Disposable().AddDisposable(disposable)
 	})
 }
 
-// Utility function to convert a function signature.
-// The number of inputs and outputs between the input function and the desired
-// signature must be the same.
+// Returns a signal that will skip the first `count` values from the
+// receiver, then forward everything afterward.
+func (signal *signal) Skip(count int) Signal {
+	if count < 0 {
+		panic("Signal.Skip: count parameter should be >= 0")
+	}
+
+	if count == 0 {
+		return signal
+	}
+
+	return signal.mapAccumulate(0, func(n interface{}, value T) (interface{}, U) {
+		if n.(int) < count {
+			return n.(int) + 1, NewEmptySignal()
+		}
+		return n, NewSingleSignal(value)
+	}).Merge()
+}
+
+// Returns a signal that will forward all but the last `count` values from
+// the receiver.
+//
+// Values are delayed by `count` events, as the signal has to buffer them to
+// know whether they are part of the last `count` ones.
+func (signal *signal) SkipLast(count int) Signal {
+	if count < 0 {
+		panic("Signal.SkipLast: count parameter should be >= 0")
+	}
+
+	if count == 0 {
+		return signal
+	}
+
+	// The buffer starts out nil, so that each subscription allocates its own
+	// backing array instead of appending to a shared one.
+	return signal.mapAccumulate([]T(nil), func(b interface{}, value T) (interface{}, U) {
+		buffer := append(b.([]T), value)
+		if len(buffer) > count {
+			return buffer[1:], NewSingleSignal(buffer[0])
+		}
+		return buffer, NewEmptySignal()
+	}).Merge()
+}
+
+// Returns a signal that will skip values from the receiver while
+// `predicate` remains `true`, then forward everything afterward.
+func (signal *signal) SkipWhile(predicate func(T) bool) Signal {
+	return signal.mapAccumulate(true, func(skipping interface{}, value T) (interface{}, U) {
+		if skipping.(bool) && predicate(value) {
+			return true, NewEmptySignal()
+		}
+		return false, NewSingleSignal(value)
+	}).Merge()
+}
+
+func (signal *signal) SkipWhileAuto(p interface{}) Signal {
+	f, err := castFunc(p, (func(T) bool)(nil))
+	if err != nil {
+		panic(err)
+	}
+	return signal.SkipWhile(f.(func(T) bool))
+}
+
+// Returns a signal that will skip values from the receiver until `trigger`
+// sends a value, then forward everything afterward.
+//
+// If `trigger` completes without sending any value, no value from the
+// receiver will ever be forwarded.
+func (signal *signal) SkipUntil(trigger Signal) Signal {
+	return NewSignal(func(subscriber Subscriber) {
+		subscriber = NewSerializedSubscriber(subscriber)
+		skipping := NewAtomic(true)
+		triggerDisposable := NewSerialDisposable(nil)
+		subscriber.Disposable().AddDisposable(triggerDisposable)
+
+		triggerDisposable.SetInnerDisposable(trigger.SubscribeFunc(
+			func(_ T) {
+				skipping.SetValue(false)
+				triggerDisposable.Dispose()
+			},
+			func(err error) {
+				subscriber.OnError(err)
+			},
+			nil,
+		))
+
+		disposable := signal.SubscribeFunc(
+			func(value T) {
+				if skipping.Value().(bool) == false {
+					subscriber.OnNext(value)
+				}
+			},
+			func(err error) {
+				subscriber.OnError(err)
+			},
+			func() {
+				subscriber.OnCompleted()
+			},
+		)
+
+		subscriber.Disposable().AddDisposable(disposable)
+	})
+}
+
+// Forwards only those values from the receiver that are not equal to the
+// value immediately preceding them.
+//
+// If `equal` is nil, values are compared with `==`.
+func (signal *signal) DistinctUntilChanged(equal func(T, T) bool) Signal {
+	if equal == nil {
+		equal = func(a, b T) bool {
+			return a == b
+		}
+	}
+
+	type lastValue struct {
+		value T
+		isSet bool
+	}
+
+	return signal.mapAccumulate(lastValue{}, func(last interface{}, value T) (interface{}, U) {
+		l := last.(lastValue)
+		if l.isSet && equal(l.value, value) {
+			return l, NewEmptySignal()
+		}
+		return lastValue{value, true}, NewSingleSignal(value)
+	}).Merge()
+}
+
+func (signal *signal) DistinctUntilChangedAuto(p interface{}) Signal {
+	f, err := castFunc(p, (func(T, T) bool)(nil))
+	if err != nil {
+		panic(err)
+	}
+	return signal.DistinctUntilChanged(f.(func(T, T) bool))
+}
+
+// Forwards only those values from the receiver whose key, as returned by
+// `key`, has not been seen before.
+//
+// Keys must be comparable. If `key` is nil, values are used as their own keys.
+// Every key is retained for the lifetime of the subscription, see
+// DistinctBounded to cap memory usage on infinite streams.
+func (signal *signal) Distinct(key func(T) interface{}) Signal {
+	return signal.distinct(0, key)
+}
+
+func (signal *signal) DistinctAuto(p interface{}) Signal {
+	f, err := castFunc(p, (func(T) interface{})(nil))
+	if err != nil {
+		panic(err)
+	}
+	return signal.Distinct(f.(func(T) interface{}))
+}
+
+// Like Distinct, but only remembers the `size` most recently seen keys.
+//
+// A value whose key has been evicted from the seen-set will be forwarded
+// again.
+func (signal *signal) DistinctBounded(size int, key func(T) interface{}) Signal {
+	if size <= 0 {
+		panic("Signal.DistinctBounded: size parameter should be > 0")
+	}
+	return signal.distinct(size, key)
+}
+
+func (signal *signal) DistinctBoundedAuto(size int, p interface{}) Signal {
+	f, err := castFunc(p, (func(T) interface{})(nil))
+	if err != nil {
+		panic(err)
+	}
+	return signal.DistinctBounded(size, f.(func(T) interface{}))
+}
+
+func (signal *signal) distinct(size int, key func(T) interface{}) Signal {
+	if key == nil {
+		key = func(value T) interface{} {
+			return value
+		}
+	}
+
+	return NewSignal(func(subscriber Subscriber) {
+		seen := NewAtomic(newKeySet(size))
+		signal.Filter(func(value T) bool {
+			k := key(value)
+			return seen.WithValue(func(s interface{}) interface{} {
+				return s.(*keySet).Add(k)
+			}).(bool)
+		}).Subscribe(subscriber)
+	})
+}
+
+// Returns a signal that will resubscribe to the receiver when it errors,
+// up to `count` times.
+//
+// If the receiver keeps erroring after `count` retries, the last error is
+// forwarded.
+func (signal *signal) Retry(count int) Signal {
+	if count < 0 {
+		panic("Signal.Retry: count parameter should be >= 0")
+	}
+
+	return signal.RetryWhen(func(errors Signal) Signal {
+		return errors.Scan(retryAttempt{}, func(previous U, err T) U {
+			return retryAttempt{previous.(retryAttempt).count + 1, err.(error)}
+		}).Map(func(a T) U {
+			if attempt := a.(retryAttempt); attempt.count > count {
+				return NewErrorSignal(attempt.err)
+			}
+			return NewSingleSignal(a)
+		}).Merge()
+	})
+}
+
+type retryAttempt struct {
+	count int
+	err   error
+}
+
+// Returns a signal that will resubscribe to the receiver whenever the
+// signal returned by `handler` sends a value.
+//
+// `handler` is given a signal of the errors sent by the receiver. If the
+// signal it returns completes or errors, the returned signal does the same.
+func (signal *signal) RetryWhen(handler func(errors Signal) Signal) Signal {
+	return NewSignal(func(subscriber Subscriber) {
+		subscriber = NewSerializedSubscriber(subscriber)
+		errorSubscribers := NewAtomic(make([]Subscriber, 0, 1))
+		errors := NewSignal(func(s Subscriber) {
+			errorSubscribers.Modify(func(ss interface{}) interface{} {
+				return append(ss.([]Subscriber), s)
+			})
+		})
+
+		sourceDisposable := NewSerialDisposable(nil)
+		subscriber.Disposable().AddDisposable(sourceDisposable)
+
+		subscribe := func() {
+			if subscriber.Disposable().IsDisposed() {
+				return
+			}
+			sourceSubscriber := NewSubscriber(
+				func(value T) {
+					subscriber.OnNext(value)
+				},
+				func(err error) {
+					for _, s := range errorSubscribers.Value().([]Subscriber) {
+						s.OnNext(err)
+					}
+				},
+				func() {
+					subscriber.OnCompleted()
+				},
+			)
+			sourceDisposable.SetInnerDisposable(sourceSubscriber.Disposable())
+			signal.Subscribe(sourceSubscriber)
+		}
+
+		// Resubscriptions requested while subscribing, e.g. by a source that
+		// fails synchronously, are counted and performed by the loop below
+		// once the current subscription returns, instead of nesting a new
+		// subscription in the stack at each attempt.
+		requests := NewAtomic(0)
+		subscribeToSource := func() {
+			if requests.Modify(func(n interface{}) interface{} {
+				return n.(int) + 1
+			}).(int) > 0 {
+				return
+			}
+			for {
+				subscribe()
+				if requests.Modify(func(n interface{}) interface{} {
+					return n.(int) - 1
+				}).(int) == 1 {
+					return
+				}
+			}
+		}
+
+		triggerDisposable := handler(errors).SubscribeFunc(
+			func(_ T) {
+				subscribeToSource()
+			},
+			func(err error) {
+				subscriber.OnError(err)
+			},
+			func() {
+				subscriber.OnCompleted()
+			},
+		)
+		subscriber.Disposable().AddDisposable(triggerDisposable)
+
+		if subscriber.Disposable().IsDisposed() == false {
+			subscribeToSource()
+		}
+	})
+}
+
+// Creates a RetryWhen handler that retries up to `maxRetries` times, waiting
+// exponentially longer between attempts.
+//
+// The n-th retry waits for `initialDelay * 2^(n-1)`, capped at `maxDelay`,
+// of which a random half is jittered away to avoid synchronized retries.
+// Delays are measured with `scheduler`.
+func NewExponentialBackoff(maxRetries int, initialDelay time.Duration, maxDelay time.Duration, scheduler Scheduler) func(Signal) Signal {
+	return func(errors Signal) Signal {
+		return errors.Scan(retryAttempt{}, func(previous U, err T) U {
+			return retryAttempt{previous.(retryAttempt).count + 1, err.(error)}
+		}).Map(func(a T) U {
+			attempt := a.(retryAttempt)
+			if attempt.count > maxRetries {
+				return NewErrorSignal(attempt.err)
+			}
+
+			delay := initialDelay
+			for i := 1; i < attempt.count && delay < maxDelay; i++ {
+				delay *= 2
+			}
+			if delay > maxDelay {
+				delay = maxDelay
+			}
+			delay = delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+
+			return NewTimerSignal(delay, scheduler)
+		}).Merge()
+	}
+}
+
+// Returns a signal that will forward events from the receiver until it
+// errors, then switch to the signal returned by `handler`.
+//
+// If `handler` returns nil, the error is forwarded unchanged. This allows
+// handlers to only recover from specific errors, e.g. using `errors.Is`.
+func (signal *signal) Catch(handler func(error) Signal) Signal {
+	return NewSignal(func(subscriber Subscriber) {
+		serialDisposable := NewSerialDisposable(nil)
+		subscriber.Disposable().AddDisposable(serialDisposable)
+
+		sourceSubscriber := NewSubscriber(
+			func(value T) {
+				subscriber.OnNext(value)
+			},
+			func(err error) {
+				var fallback Signal
+				if panicErr := catchPanic(func() { fallback = handler(err) }); panicErr != nil {
+					subscriber.OnError(panicErr)
+					return
+				}
+				if fallback == nil {
+					subscriber.OnError(err)
+					return
+				}
+				serialDisposable.SetInnerDisposable(fallback.SubscribeFunc(
+					func(value T) {
+						subscriber.OnNext(value)
+					},
+					func(err error) {
+						subscriber.OnError(err)
+					},
+					func() {
+						subscriber.OnCompleted()
+					},
+				))
+			},
+			func() {
+				subscriber.OnCompleted()
+			},
+		)
+		serialDisposable.SetInnerDisposable(sourceSubscriber.Disposable())
+		signal.Subscribe(sourceSubscriber)
+	})
+}
+
+// Like Catch, but `p` can accept any error type. The handler is only called
+// for errors matching that type according to `errors.As`, other errors are
+// forwarded unchanged.
+func (signal *signal) CatchAuto(p interface{}) Signal {
+	handler, err := castErrorHandler(p)
+	if err != nil {
+		panic(err)
+	}
+	return signal.Catch(func(err error) Signal {
+		fallback, ok := handler(err)
+		if !ok || fallback == nil {
+			return nil
+		}
+		return fallback.(Signal)
+	})
+}
+
+// Returns a signal that will forward events from the receiver until it
+// errors, then send the value returned by `handler` and complete.
+func (signal *signal) OnErrorReturn(handler func(error) T) Signal {
+	return signal.Catch(func(err error) Signal {
+		return NewSingleSignal(handler(err))
+	})
+}
+
+// Like OnErrorReturn, but `p` can accept any error type. The handler is only
+// called for errors matching that type according to `errors.As`, other errors
+// are forwarded unchanged.
+func (signal *signal) OnErrorReturnAuto(p interface{}) Signal {
+	handler, err := castErrorHandler(p)
+	if err != nil {
+		panic(err)
+	}
+	return signal.Catch(func(err error) Signal {
+		value, ok := handler(err)
+		if !ok {
+			return nil
+		}
+		return NewSingleSignal(value)
+	})
+}
+
+// Returns a signal that will forward events from the receiver until it
+// errors, then forward events from `next`.
+func (signal *signal) OnErrorResumeNext(next Signal) Signal {
+	return signal.Catch(func(_ error) Signal {
+		return next
+	})
+}
+
+// Returns a signal that will forward events from the receiver, or error
+// with ErrTimeout if `interval` elapses before the first value or between
+// two consecutive values.
+//
+// The receiver is disposed of when the timeout expires.
+func (signal *signal) Timeout(interval time.Duration, scheduler Scheduler) Signal {
+	return signal.timeout(interval, scheduler, true, nil)
+}
+
+// Returns a signal that will forward events from the receiver, or error
+// with ErrTimeout if the receiver does not terminate within `interval`.
+//
+// The receiver is disposed of when the timeout expires.
+func (signal *signal) TimeoutTotal(interval time.Duration, scheduler Scheduler) Signal {
+	return signal.timeout(interval, scheduler, false, nil)
+}
+
+// Like Timeout, but switches to `fallback` instead of erroring when the
+// timeout expires.
+func (signal *signal) TimeoutWith(interval time.Duration, fallback Signal, scheduler Scheduler) Signal {
+	return signal.timeout(interval, scheduler, true, fallback)
+}
+
+// Forwards events from the receiver while restarting a timer on each value
+// if `perValue` is true. When the timer fires the receiver is disposed of and
+// the returned signal either errors with ErrTimeout or switches to `fallback`.
+func (signal *signal) timeout(interval time.Duration, scheduler Scheduler, perValue bool, fallback Signal) Signal {
+	return NewSignal(func(subscriber Subscriber) {
+		subscriber = NewSerializedSubscriber(subscriber)
+		// Counts the values received so far, so that a timer only fires if no
+		// value arrived since it was started. A negative count means that
+		// the signal has terminated.
+		received := NewAtomic(0)
+		timerDisposable := NewSerialDisposable(nil)
+		sourceDisposable := NewSerialDisposable(nil)
+		subscriber.Disposable().AddDisposable(timerDisposable)
+		subscriber.Disposable().AddDisposable(sourceDisposable)
+
+		startTimer := func(count int) {
+			timerDisposable.SetInnerDisposable(scheduler.ScheduleAfter(interval, func() {
+				_, expired := received.ModifyData(func(c interface{}) (interface{}, interface{}) {
+					if c.(int) != count {
+						return c, false
+					}
+					return -1, true
+				})
+				if expired == false {
+					return
+				}
+
+				sourceDisposable.Dispose()
+				if fallback == nil {
+					subscriber.OnError(ErrTimeout)
+					return
+				}
+				subscriber.Disposable().AddDisposable(fallback.SubscribeFunc(
+					func(value T) {
+						subscriber.OnNext(value)
+					},
+					func(err error) {
+						subscriber.OnError(err)
+					},
+					func() {
+						subscriber.OnCompleted()
+					},
+				))
+			}))
+		}
+
+		terminate := func() bool {
+			orig := received.Swap(-1)
+			return orig.(int) >= 0
+		}
+
+		sourceSubscriber := NewSubscriber(
+			func(value T) {
+				_, count := received.ModifyData(func(c interface{}) (interface{}, interface{}) {
+					if c.(int) < 0 || perValue == false {
+						return c, c
+					}
+					return c.(int) + 1, c.(int) + 1
+				})
+				if count.(int) < 0 {
+					return
+				}
+				subscriber.OnNext(value)
+				if perValue {
+					startTimer(count.(int))
+				}
+			},
+			func(err error) {
+				if terminate() {
+					subscriber.OnError(err)
+				}
+			},
+			func() {
+				if terminate() {
+					subscriber.OnCompleted()
+				}
+			},
+		)
+		sourceDisposable.SetInnerDisposable(sourceSubscriber.Disposable())
+
+		startTimer(0)
+		signal.Subscribe(sourceSubscriber)
+	})
+}
+
+// Returns a signal that will forward values and completion from the
+// receiver after `interval`, measured with `scheduler`.
+//
+// Errors are forwarded immediately, dropping any pending value.
+func (signal *signal) Delay(interval time.Duration, scheduler Scheduler) Signal {
+	return signal.delay(interval, scheduler, false)
+}
+
+// Like Delay, but errors are delayed as well.
+func (signal *signal) DelayAll(interval time.Duration, scheduler Scheduler) Signal {
+	return signal.delay(interval, scheduler, true)
+}
+
+type delayedEvent struct {
+	due          time.Time
+	notification Notification
+}
+
+type delayState struct {
+	events    []delayedEvent
+	scheduled bool
+}
+
+// Forwards events from the receiver after `interval`, preserving their
+// order.
+//
+// Only one timer is pending at any time, for the oldest event not sent yet,
+// so that events are never sent concurrently.
+func (signal *signal) delay(interval time.Duration, scheduler Scheduler, delayErrors bool) Signal {
+	return NewSignal(func(subscriber Subscriber) {
+		subscriber = NewSerializedSubscriber(subscriber)
+		state := NewAtomic(delayState{})
+		timerDisposable := NewSerialDisposable(nil)
+		subscriber.Disposable().AddDisposable(timerDisposable)
+
+		var sendDue func()
+		schedule := func(due time.Time) {
+			timerDisposable.SetInnerDisposable(scheduler.ScheduleAfter(due.Sub(scheduler.Now()), sendDue))
+		}
+		sendDue = func() {
+			for {
+				_, next := state.ModifyData(func(s interface{}) (interface{}, interface{}) {
+					st := s.(delayState)
+					if len(st.events) == 0 {
+						return delayState{nil, false}, nil
+					}
+					if st.events[0].due.After(scheduler.Now()) {
+						return st, st.events[0].due
+					}
+					return delayState{st.events[1:], true}, st.events[0]
+				})
+				switch n := next.(type) {
+				case delayedEvent:
+					n.notification.Accept(subscriber)
+				case time.Time:
+					schedule(n)
+					return
+				default:
+					return
+				}
+			}
+		}
+		enqueue := func(notification Notification) {
+			due := scheduler.Now().Add(interval)
+			_, shouldSchedule := state.ModifyData(func(s interface{}) (interface{}, interface{}) {
+				st := s.(delayState)
+				events := append(st.events, delayedEvent{due, notification})
+				return delayState{events, true}, st.scheduled == false
+			})
+			if shouldSchedule.(bool) {
+				schedule(due)
+			}
+		}
+
+		disposable := signal.SubscribeFunc(
+			func(value T) {
+				enqueue(NewNextNotification(value))
+			},
+			func(err error) {
+				if delayErrors {
+					enqueue(NewErrorNotification(err))
+					return
+				}
+				state.SetValue(delayState{nil, true})
+				timerDisposable.Dispose()
+				subscriber.OnError(err)
+			},
+			func() {
+				enqueue(NewCompletedNotification())
+			},
+		)
+		subscriber.Disposable().AddDisposable(disposable)
+	})
+}
+
+// Returns a signal that will subscribe to the receiver only after
+// `interval`, measured with `scheduler`.
+//
+// Disposing of the returned signal before then cancels the subscription.
+func (signal *signal) DelaySubscription(interval time.Duration, scheduler Scheduler) Signal {
+	return NewSignal(func(subscriber Subscriber) {
+		subscriber.Disposable().AddDisposable(scheduler.ScheduleAfter(interval, func() {
+			signal.Subscribe(subscriber)
+		}))
+	})
+}
+
+// Returns a signal that will forward all events from the receiver, calling
+// the given functions before each event is forwarded.
+//
+// Any of the functions may be nil.
+func (signal *signal) Do(next func(T), err func(error), completed func()) Signal {
+	return NewSignal(func(subscriber Subscriber) {
+		disposable := signal.SubscribeFunc(
+			func(value T) {
+				if next != nil {
+					next(value)
+				}
+				subscriber.OnNext(value)
+			},
+			func(e error) {
+				if err != nil {
+					err(e)
+				}
+				subscriber.OnError(e)
+			},
+			func() {
+				if completed != nil {
+					completed()
+				}
+				subscriber.OnCompleted()
+			},
+		)
+		subscriber.Disposable().AddDisposable(disposable)
+	})
+}
+
+// Returns a signal that will call `action` each time it is subscribed to,
+// right before subscribing to the receiver.
+func (signal *signal) OnSubscribe(action func()) Signal {
+	return NewSignal(func(subscriber Subscriber) {
+		action()
+		signal.Subscribe(subscriber)
+	})
+}
+
+// Returns a signal that will call `action` when its subscriber is disposed
+// of, before the receiver is.
+//
+// This happens whether the subscriber got disposed of because of completion,
+// error, or external disposal.
+func (signal *signal) OnDispose(action func()) Signal {
+	return NewSignal(func(subscriber Subscriber) {
+		subscriber.Disposable().AddDisposableFunc(func() error {
+			action()
+			return nil
+		})
+		signal.Subscribe(subscriber)
+	})
+}
+
+// Returns a signal that will call `action` once the receiver has completed,
+// errored or been disposed of, after the subscriber has received the final
+// event.
+func (signal *signal) Finally(action func()) Signal {
+	return NewSignal(func(subscriber Subscriber) {
+		signal.Subscribe(subscriber)
+		subscriber.Disposable().AddDisposableFunc(func() error {
+			action()
+			return nil
+		})
+	})
+}
+
+// Returns a signal that will send every event from the receiver, including
+// errors and completion, as a Notification value, then complete.
+func (signal *signal) Materialize() Signal {
+	return NewSignal(func(subscriber Subscriber) {
+		disposable := signal.SubscribeFunc(
+			func(value T) {
+				subscriber.OnNext(NewNextNotification(value))
+			},
+			func(err error) {
+				subscriber.OnNext(NewErrorNotification(err))
+				subscriber.OnCompleted()
+			},
+			func() {
+				subscriber.OnNext(NewCompletedNotification())
+				subscriber.OnCompleted()
+			},
+		)
+		subscriber.Disposable().AddDisposable(disposable)
+	})
+}
+
+// The inverse of Materialize: returns a signal that will send the events
+// represented by the Notification values sent by the receiver.
+//
+// The returned signal errors if the receiver sends a value that is not a
+// Notification.
+func (signal *signal) Dematerialize() Signal {
+	return NewSignal(func(subscriber Subscriber) {
+		upstream := NewSubscriber(
+			func(value T) {
+				notification, ok := value.(Notification)
+				if !ok {
+					subscriber.OnError(errors.New(fmt.Sprintf("Expect type Notification got %T", value)))
+					return
+				}
+				notification.Accept(subscriber)
+			},
+			func(err error) {
+				subscriber.OnError(err)
+			},
+			func() {
+				subscriber.OnCompleted()
+			},
+		)
+		subscriber.Disposable().AddDisposable(upstream.Disposable())
+		signal.Subscribe(upstream)
+	})
+}
+
+// Returns a connectable signal that will forward events from the receiver
+// to all of its subscribers through a single subscription, once connected.
+func (signal *signal) Publish() ConnectableSignal {
+	return NewConnectableSignal(signal, NewPublishSubject)
+}
+
+// Returns a signal that shares a single subscription to the receiver among
+// all of its subscribers, subscribing on the first one and disposing of the
+// subscription after the last one is disposed of.
+func (signal *signal) Share() Signal {
+	return signal.Publish().RefCount()
+}
+
+// Returns a connectable signal that, once connected, will forward events
+// from the receiver to all of its subscribers and replay past values to late
+// subscribers.
+//
+// At most the `size` latest values are replayed, and only those sent less
+// than `window` ago according to `scheduler`. A `size` or `window` <= 0
+// means that no such bound applies, in which case `scheduler` may be nil.
+func (signal *signal) Replay(size int, window time.Duration, scheduler Scheduler) ConnectableSignal {
+	return NewConnectableSignal(signal, func() Subject {
+		return NewReplaySubject(size, window, scheduler)
+	})
+}
+
+// Returns a signal that subscribes to the receiver on its first subscriber,
+// and replays all of its events to every subscriber, including after the
+// receiver terminated.
+//
+// Call Invalidate on the returned signal to drop the cached events. Errors
+// are cached too, so a failed receiver is only subscribed to again after
+// Invalidate.
+func (signal *signal) Cache() CachedSignal {
+	return NewCachedSignal(signal)
+}
+
+// Returns a backpressure signal that buffers the values sent by the receiver
+// until they are requested.
+//
+// At most `size` values are buffered beyond the requested ones, and
+// `strategy` decides what happens to values that do not fit. A `size` <= 0
+// means that the buffer is unbounded.
+func (signal *signal) OnBackpressureBuffer(size int, strategy OverflowStrategy) BackpressureSignal {
+	if size <= 0 {
+		size = -1
+	}
+	return newBackpressureSignal(signal, size, strategy)
+}
+
+// Returns a backpressure signal that drops the values sent by the receiver
+// while none are requested.
+func (signal *signal) OnBackpressureDrop() BackpressureSignal {
+	return newBackpressureSignal(signal, 0, OverflowDropLatest)
+}
+
+// Returns a backpressure signal that only keeps the latest value sent by the
+// receiver while none are requested, and sends it on the next request.
+func (signal *signal) OnBackpressureLatest() BackpressureSignal {
+	return newBackpressureSignal(signal, 1, OverflowDropOldest)
+}
+
+// Returns a signal that will forward events from the receiver, ensuring
+// that they are never delivered concurrently to the subscriber.
+func (signal *signal) Serialize() Signal {
+	return NewSignal(func(subscriber Subscriber) {
+		signal.Subscribe(NewSerializedSubscriber(subscriber))
+	})
+}
+
+// Merges a signal of signals down into a single signal, biased toward the
+// signals added earlier.
+//
+// Returns a signal that will forward events from the original signals
+// as they arrive.
+func (signal *signal) Merge() Signal {
+	return NewSignal(func(subscriber Subscriber) {
+		subscriber = NewSerializedSubscriber(subscriber)
+		disposable := NewCompositeDisposable(nil)
+		subscriber.Disposable().AddDisposable(disposable)
+		inFlight := NewAtomic(1)
+
+		decrementInFlight := func() {
+			orig := inFlight.Modify(func(v interface{}) interface{} {
+				return v.(int) - 1
+			})
+			if orig == 1 {
+				subscriber.OnCompleted()
+			}
+		}
+
+		selfDisposable := signal.SubscribeFunc(
+			func(stream T) {
+				inFlight.Modify(func(v interface{}) interface{} {
+					return v.(int) + 1
+				})
+
+				streamDisposable := NewSerialDisposable(nil)
+				handle := disposable.Add(streamDisposable)
+
+				streamDisposable.SetInnerDisposable(stream.(Signal).SubscribeFunc(
+					func(value T) {
+						subscriber.OnNext(value.(interface{}))
+					},
+					func(err error) {
+						streamDisposable.Dispose()
+						disposable.Remove(handle)
+						subscriber.OnError(err)
+					},
+					func() {
+						streamDisposable.Dispose()
+						disposable.Remove(handle)
+						decrementInFlight()
+					},
+				))
+			},
+			func(err error) {
+				subscriber.OnError(err)
+			},
+			func() {
+				decrementInFlight()
+			},
+		)
+
+		subscriber.Disposable().AddDisposable(selfDisposable)
+	})
+}
+
+// Concatenates each inner signal with the previous and next inner signals.
+//
+// Returns a signal that will forward events from each of the original
+// signals, in sequential order.
+func (signal *signal) Concat() Signal {
+	return NewSignal(func(subscriber Subscriber) {
+		subscriber = NewSerializedSubscriber(subscriber)
+		state := NewAtomic(concatState{})
+		innerDisposable := NewSerialDisposable(nil)
+		subscriber.Disposable().AddDisposable(innerDisposable)
+
+		var subscribeToSignal func(Signal)
+		subscribeToNextSignal := func() {
+			_, next := state.ModifyData(func(s interface{}) (interface{}, interface{}) {
+				st := s.(concatState)
+				if len(st.pending) > 0 {
+					return concatState{st.pending[1:], true, st.completed}, st.pending[0]
+				}
+				return concatState{nil, false, st.completed}, st.completed
+			})
+			switch n := next.(type) {
+			case Signal:
+				subscribeToSignal(n)
+			case bool:
+				if n {
+					subscriber.OnCompleted()
+				}
+			}
+		}
+		subscribeToSignal = func(s Signal) {
+			signalSubscriber := NewSubscriber(
+				func(v T) {
+					subscriber.OnNext(v)
+				},
+				func(err error) {
+					subscriber.OnError(err)
+				},
+				func() {
+					subscribeToNextSignal()
+				},
+			)
+			innerDisposable.SetInnerDisposable(signalSubscriber.Disposable())
+			s.Subscribe(signalSubscriber)
+		}
+
+		selfDisposable := signal.SubscribeFunc(
+			func(s T) {
+				_, subscribeNow := state.ModifyData(func(st interface{}) (interface{}, interface{}) {
+					cs := st.(concatState)
+					if cs.active {
+						return concatState{append(cs.pending, s.(Signal)), true, cs.completed}, false
+					}
+					return concatState{cs.pending, true, cs.completed}, true
+				})
+				if subscribeNow.(bool) {
+					subscribeToSignal(s.(Signal))
+				}
+			},
+			func(err error) {
+				subscriber.OnError(err)
+			},
+			func() {
+				_, completeNow := state.ModifyData(func(st interface{}) (interface{}, interface{}) {
+					cs := st.(concatState)
+					return concatState{cs.pending, cs.active, true}, cs.active == false
+				})
+				if completeNow.(bool) {
+					subscriber.OnCompleted()
+				}
+			},
+		)
+
+		subscriber.Disposable().AddDisposable(selfDisposable)
+	})
+}
+
+// The state of a Concat subscription: the inner signals waiting for the
+// active one to complete, whether one is active, and whether the outer
+// signal completed.
+type concatState struct {
+	pending   []Signal
+	active    bool
+	completed bool
+}
+
+/// Concatenates the given signal after the receiver.
+func (signal *signal) ConcatWith(s Signal) Signal {
+	return NewValuesSignal([]interface{}{signal, s}).Concat()
+}
+
+// Returns a signal that will send the given values, then forward events from
+// the receiver.
+func (signal *signal) StartWith(values ...interface{}) Signal {
+	return NewValuesSignal(values).ConcatWith(signal)
+}
+
+// Returns a signal that will forward events from the receiver, then send the
+// given values once it completes.
+func (signal *signal) EndWith(values ...interface{}) Signal {
+	return signal.ConcatWith(NewValuesSignal(values))
+}
+
+// Maps over the elements of the signal, accumulating a state along the
+// way.
+//
+// This is meant as a primitive operator from which more complex operators
+// can be built.
+//
+// Yielding a `nil` state at any point will stop evaluation of the original
+// signal, and dispose of it.
+//
+// Returns a signal of the mapped values.
+func (signal *signal) mapAccumulate(initialState interface{}, f func(state interface{}, current T) (newState interface{}, newValue U)) Signal {
+	return NewSignal(func(subscriber Subscriber) {
+		state := NewAtomic(initialState)
+		upstream := NewSubscriber(
+			// Next
+			func(value T) {
+				// Events are serialized below, so `f` can run without holding
+				// the state: events it sends re-entrantly are queued until
+				// this one has updated the state and been forwarded.
+				newState, newValue := f(state.Value(), value)
+				if newState != nil {
+					state.SetValue(newState)
+				}
+				subscriber.OnNext(newValue)
+
+				if newState == nil {
+					subscriber.OnCompleted()
+				}
+			},
+			// Error
+			func(err error) {
+				subscriber.OnError(err)
+			},
+			// Completed
+			func() {
+				subscriber.OnCompleted()
+			},
+		)
+		// The upstream disposable is added before subscribing, so that
+		// synchronous signals stop sending events as soon as evaluation stops.
+		subscriber.Disposable().AddDisposable(upstream.Disposable())
+		signal.Subscribe(NewSerializedSubscriber(upstream))
+	})
+}
+
+// Utility function to convert a function signature.
+// The number of inputs and outputs between the input function and the desired
+// signature must be the same.
 // If the usual Go conversion rules do not allow conversion of inputs and outputs,
 // castFunc panics.
 // Typical usage (without error management):
@@ -506,3 +1938,31 @@ func castFunc(p interface{}, to interface{}) (interface{}, error) {
 	})
 	return funcValue.Interface(), nil
 }
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// Utility function to convert a function accepting a single error of any type
+// and returning a single value into a function accepting any error.
+// The returned function uses `errors.As` to match the given error against the
+// input type of `p`, and reports whether it did match.
+func castErrorHandler(p interface{}) (func(error) (interface{}, bool), error) {
+	pT := reflect.TypeOf(p)
+	if pT == nil || pT.Kind() != reflect.Func {
+		return nil, errors.New(fmt.Sprintf("Invalid parameter kind (%v) expecting function", pT))
+	}
+	if pT.NumIn() != 1 || pT.NumOut() != 1 {
+		return nil, errors.New(fmt.Sprintf("Invalid function %v expecting one input and one output", pT))
+	}
+	errT := pT.In(0)
+	if errT.Kind() != reflect.Interface && errT.Implements(errorType) == false {
+		return nil, errors.New(fmt.Sprintf("Invalid function input (%v) expecting an error type", errT))
+	}
+	pV := reflect.ValueOf(p)
+	return func(err error) (interface{}, bool) {
+		target := reflect.New(errT)
+		if errors.As(err, target.Interface()) == false {
+			return nil, false
+		}
+		return pV.Call([]reflect.Value{target.Elem()})[0].Interface(), true
+	}, nil
+}
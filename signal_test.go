@@ -1,7 +1,11 @@
 package main
 
 import (
+	"errors"
+	"fmt"
+	"runtime"
 	"testing"
+	"time"
 )
 
 func TestMapShouldMapInput(t *testing.T) {
@@ -67,6 +71,43 @@ func TestScan(t *testing.T) {
 	}
 }
 
+func TestScanShouldAllowReentrantEvents(t *testing.T) {
+	var source Subscriber
+	result := make([]int, 0)
+	expected := []int{1, 11, 111}
+	done := make(chan struct{})
+
+	NewSignal(func(s Subscriber) {
+		source = s
+	}).Scan(0, func(state U, current T) U {
+		if current.(int) == 1 {
+			source.OnNext(10)
+		}
+		return state.(int) + current.(int)
+	}).SubscribeAuto(func(v int) {
+		result = append(result, v)
+	})
+	go func() {
+		source.OnNext(1)
+		source.OnNext(100)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Expect re-entrant events not to deadlock")
+	}
+	if len(result) != len(expected) {
+		t.Fatalf("Expecting `len(result)` to equal %v got %v", len(expected), len(result))
+	}
+	for i, v := range expected {
+		if v != result[i] {
+			t.Fatalf("Expecting %v to equal %v", result, expected)
+		}
+	}
+}
+
 func TestReduce(t *testing.T) {
 	signal := NewValuesSignal([]interface{}{1, 2, 3, 4, 5, 6})
 	result := make([]int, 0, 1)
@@ -170,6 +211,64 @@ func TestMergeShouldMergeSignals(t *testing.T) {
 	}
 }
 
+func TestMergeShouldDisposeOfInnerSignals(t *testing.T) {
+	disposed := false
+	inner := NewSignal(func(subscriber Subscriber) {
+		subscriber.Disposable().AddDisposableFunc(func() error {
+			disposed = true
+			return nil
+		})
+	})
+
+	disposable := NewValuesSignal([]interface{}{NewSingleSignal(1), inner}).Merge().SubscribeFunc(nil, nil, nil)
+	if disposed != false {
+		t.Error("Expect `disposed` to be false")
+	}
+	disposable.Dispose()
+
+	if disposed != true {
+		t.Error("Expect disposing of the merged signal to dispose of `inner`")
+	}
+}
+
+func TestConcatShouldWaitForAsynchronousSignals(t *testing.T) {
+	scheduler := NewTestScheduler(time.Time{})
+	result := make([]int, 0)
+	expected := []int{1, 2, 3, 4, 5}
+	completed := false
+
+	NewValuesSignal([]interface{}{
+		NewValuesSignal([]interface{}{1, 2}).Delay(10*time.Millisecond, scheduler),
+		NewValuesSignal([]interface{}{3, 4}).Delay(5*time.Millisecond, scheduler),
+		NewSingleSignal(5),
+	}).Concat().SubscribeAuto(func(v int) {
+		result = append(result, v)
+	}, func() {
+		completed = true
+	})
+
+	if len(result) != 0 {
+		t.Fatalf("Expecting `len(result)` to equal 0 got %v", len(result))
+	}
+	scheduler.Advance(10 * time.Millisecond)
+	if len(result) != 2 || completed != false {
+		t.Fatalf("Expecting `result` to equal [1 2] got %v", result)
+	}
+	scheduler.Run()
+
+	if completed != true {
+		t.Error("Expect `completed` to be true")
+	}
+	if len(result) != len(expected) {
+		t.Fatalf("Expecting `len(result)` to equal %v got %v", len(expected), len(result))
+	}
+	for i, v := range expected {
+		if v != result[i] {
+			t.Fatalf("Expecting %v to equal %v", result, expected)
+		}
+	}
+}
+
 func TestConcatWith(t *testing.T) {
 	result := make([]int, 0)
 	expected := []int{1, 2, 3, 4, 5, 6}
@@ -190,3 +289,1197 @@ func TestConcatWith(t *testing.T) {
 		}
 	}
 }
+
+func TestSkip(t *testing.T) {
+	signal := NewValuesSignal([]interface{}{1, 2, 3, 4, 5, 6})
+	result := make([]int, 0)
+	expected := []int{5, 6}
+
+	signal.Skip(4).SubscribeAuto(func(v int) {
+		result = append(result, v)
+	})
+
+	if len(result) != len(expected) {
+		t.Fatalf("Expecting `len(result)` to equal %v got %v", len(expected), len(result))
+	}
+	for i, v := range expected {
+		if v != result[i] {
+			t.Fatalf("Expecting %v to equal %v", result, expected)
+		}
+	}
+}
+
+func TestSkipLast(t *testing.T) {
+	signal := NewValuesSignal([]interface{}{1, 2, 3, 4, 5, 6})
+	result := make([]int, 0)
+	expected := []int{1, 2}
+
+	signal.SkipLast(4).SubscribeAuto(func(v int) {
+		result = append(result, v)
+	})
+
+	if len(result) != len(expected) {
+		t.Fatalf("Expecting `len(result)` to equal %v got %v", len(expected), len(result))
+	}
+	for i, v := range expected {
+		if v != result[i] {
+			t.Fatalf("Expecting %v to equal %v", result, expected)
+		}
+	}
+}
+
+func TestSkipLastShouldKeepSubscriptionsApart(t *testing.T) {
+	subject := NewPublishSubject()
+	skipped := subject.SkipLast(2)
+	first := make([]string, 0)
+	second := make([]string, 0)
+
+	skipped.SubscribeAuto(func(v string) {
+		first = append(first, v)
+	})
+	subject.OnNext("a1")
+	skipped.SubscribeAuto(func(v string) {
+		second = append(second, v)
+	})
+	subject.OnNext("b1")
+	subject.OnNext("c1")
+	subject.OnNext("d1")
+
+	expectedFirst := []string{"a1", "b1"}
+	expectedSecond := []string{"b1"}
+	if len(first) != len(expectedFirst) || first[0] != expectedFirst[0] || first[1] != expectedFirst[1] {
+		t.Errorf("Expect `first` to equal %v, got %v", expectedFirst, first)
+	}
+	if len(second) != len(expectedSecond) || second[0] != expectedSecond[0] {
+		t.Errorf("Expect `second` to equal %v, got %v", expectedSecond, second)
+	}
+}
+
+func TestSkipWhile(t *testing.T) {
+	signal := NewValuesSignal([]interface{}{1, 2, 3, 4, 1, 2})
+	result := make([]int, 0)
+	expected := []int{4, 1, 2}
+
+	signal.SkipWhileAuto(func(v int) bool {
+		return v < 4
+	}).SubscribeAuto(func(v int) {
+		result = append(result, v)
+	})
+
+	if len(result) != len(expected) {
+		t.Fatalf("Expecting `len(result)` to equal %v got %v", len(expected), len(result))
+	}
+	for i, v := range expected {
+		if v != result[i] {
+			t.Fatalf("Expecting %v to equal %v", result, expected)
+		}
+	}
+}
+
+func TestSkipUntil(t *testing.T) {
+	var source, trigger Subscriber
+	result := make([]int, 0)
+	expected := []int{3, 4}
+
+	NewSignal(func(s Subscriber) {
+		source = s
+	}).SkipUntil(NewSignal(func(s Subscriber) {
+		trigger = s
+	})).SubscribeAuto(func(v int) {
+		result = append(result, v)
+	})
+
+	source.OnNext(1)
+	source.OnNext(2)
+	trigger.OnNext(true)
+	source.OnNext(3)
+	source.OnNext(4)
+
+	if len(result) != len(expected) {
+		t.Fatalf("Expecting `len(result)` to equal %v got %v", len(expected), len(result))
+	}
+	for i, v := range expected {
+		if v != result[i] {
+			t.Fatalf("Expecting %v to equal %v", result, expected)
+		}
+	}
+}
+
+func TestTakeUntil(t *testing.T) {
+	var source, trigger Subscriber
+	result := make([]int, 0)
+	expected := []int{1, 2}
+	completed := false
+
+	NewSignal(func(s Subscriber) {
+		source = s
+	}).TakeUntil(NewSignal(func(s Subscriber) {
+		trigger = s
+	})).SubscribeAuto(func(v int) {
+		result = append(result, v)
+	}, func() {
+		completed = true
+	})
+
+	source.OnNext(1)
+	source.OnNext(2)
+	trigger.OnNext(true)
+	source.OnNext(3)
+
+	if completed != true {
+		t.Error("Expect `completed` to be true")
+	}
+	if source.Disposable().IsDisposed() != true {
+		t.Error("Expect `source.Disposable().IsDisposed()` to be true")
+	}
+	if len(result) != len(expected) {
+		t.Fatalf("Expecting `len(result)` to equal %v got %v", len(expected), len(result))
+	}
+	for i, v := range expected {
+		if v != result[i] {
+			t.Fatalf("Expecting %v to equal %v", result, expected)
+		}
+	}
+}
+
+func TestDistinctUntilChanged(t *testing.T) {
+	signal := NewValuesSignal([]interface{}{1, 1, 2, 2, 2, 1, 3, 3})
+	result := make([]int, 0)
+	expected := []int{1, 2, 1, 3}
+
+	signal.DistinctUntilChanged(nil).SubscribeAuto(func(v int) {
+		result = append(result, v)
+	})
+
+	if len(result) != len(expected) {
+		t.Fatalf("Expecting `len(result)` to equal %v got %v", len(expected), len(result))
+	}
+	for i, v := range expected {
+		if v != result[i] {
+			t.Fatalf("Expecting %v to equal %v", result, expected)
+		}
+	}
+}
+
+func TestDistinctUntilChangedWithCustomEquality(t *testing.T) {
+	signal := NewValuesSignal([]interface{}{1, 3, 2, 4, 5, 7})
+	result := make([]int, 0)
+	expected := []int{1, 2, 5}
+
+	signal.DistinctUntilChangedAuto(func(a int, b int) bool {
+		return a%2 == b%2
+	}).SubscribeAuto(func(v int) {
+		result = append(result, v)
+	})
+
+	if len(result) != len(expected) {
+		t.Fatalf("Expecting `len(result)` to equal %v got %v", len(expected), len(result))
+	}
+	for i, v := range expected {
+		if v != result[i] {
+			t.Fatalf("Expecting %v to equal %v", result, expected)
+		}
+	}
+}
+
+func TestDistinct(t *testing.T) {
+	signal := NewValuesSignal([]interface{}{1, 2, 11, 3, 12, 4, 21})
+	result := make([]int, 0)
+	expected := []int{1, 2, 3, 4}
+
+	signal.DistinctAuto(func(v int) interface{} {
+		return v % 10
+	}).SubscribeAuto(func(v int) {
+		result = append(result, v)
+	})
+
+	if len(result) != len(expected) {
+		t.Fatalf("Expecting `len(result)` to equal %v got %v", len(expected), len(result))
+	}
+	for i, v := range expected {
+		if v != result[i] {
+			t.Fatalf("Expecting %v to equal %v", result, expected)
+		}
+	}
+}
+
+func TestDistinctBounded(t *testing.T) {
+	signal := NewValuesSignal([]interface{}{1, 2, 1, 3, 1, 2})
+	result := make([]int, 0)
+	expected := []int{1, 2, 3, 2}
+
+	signal.DistinctBounded(2, nil).SubscribeAuto(func(v int) {
+		result = append(result, v)
+	})
+
+	if len(result) != len(expected) {
+		t.Fatalf("Expecting `len(result)` to equal %v got %v", len(expected), len(result))
+	}
+	for i, v := range expected {
+		if v != result[i] {
+			t.Fatalf("Expecting %v to equal %v", result, expected)
+		}
+	}
+}
+
+func TestRetry(t *testing.T) {
+	attempts := 0
+	signal := NewSignal(func(s Subscriber) {
+		attempts++
+		s.OnNext(attempts)
+		if attempts < 3 {
+			s.OnError(errors.New("failed"))
+		} else {
+			s.OnCompleted()
+		}
+	})
+	result := make([]int, 0)
+	expected := []int{1, 2, 3}
+	completed := false
+
+	signal.Retry(2).SubscribeAuto(func(v int) {
+		result = append(result, v)
+	}, func() {
+		completed = true
+	})
+
+	if completed != true {
+		t.Error("Expect `completed` to be true")
+	}
+	if len(result) != len(expected) {
+		t.Fatalf("Expecting `len(result)` to equal %v got %v", len(expected), len(result))
+	}
+	for i, v := range expected {
+		if v != result[i] {
+			t.Fatalf("Expecting %v to equal %v", result, expected)
+		}
+	}
+}
+
+func TestRetryWhenShouldNotGrowTheStackForSynchronousErrors(t *testing.T) {
+	depths := make([]int, 0)
+	var err error
+
+	NewSignal(func(subscriber Subscriber) {
+		depths = append(depths, runtime.Callers(0, make([]uintptr, 4096)))
+		subscriber.OnError(errors.New("failed"))
+	}).RetryWhen(func(errs Signal) Signal {
+		// Forwards errors without any operator, which could otherwise queue
+		// them and hide the nesting.
+		return NewSignal(func(subscriber Subscriber) {
+			count := 0
+			errs.SubscribeFunc(func(e T) {
+				if count++; count > 1000 {
+					subscriber.OnError(e.(error))
+					return
+				}
+				subscriber.OnNext(e)
+			}, nil, nil)
+		})
+	}).SubscribeAuto(func(e error) {
+		err = e
+	})
+
+	if len(depths) != 1001 {
+		t.Fatalf("Expecting `len(depths)` to equal 1001 got %v", len(depths))
+	}
+	if depths[1000] > depths[1] {
+		t.Errorf("Expect the stack not to grow across attempts, got %v then %v frames", depths[1], depths[1000])
+	}
+	if err == nil {
+		t.Error("Expect `err` not to be nil")
+	}
+}
+
+func TestRetryShouldForwardErrorAfterLastAttempt(t *testing.T) {
+	attempts := 0
+	signal := NewSignal(func(s Subscriber) {
+		attempts++
+		s.OnError(errors.New("failed"))
+	})
+	var err error
+
+	signal.Retry(2).SubscribeAuto(func(e error) {
+		err = e
+	})
+
+	if attempts != 3 {
+		t.Errorf("Expect `attempts` to equal 3, got %v", attempts)
+	}
+	if err == nil {
+		t.Error("Expect `err` not to be nil")
+	}
+}
+
+func TestRetryWhenWithExponentialBackoff(t *testing.T) {
+	scheduler := NewTestScheduler(time.Now())
+	attempts := 0
+	signal := NewSignal(func(s Subscriber) {
+		attempts++
+		if attempts < 3 {
+			s.OnError(errors.New("failed"))
+		} else {
+			s.OnCompleted()
+		}
+	})
+	completed := false
+
+	signal.RetryWhen(NewExponentialBackoff(5, time.Second, time.Minute, scheduler)).SubscribeAuto(func() {
+		completed = true
+	})
+
+	if attempts != 1 {
+		t.Errorf("Expect `attempts` to equal 1, got %v", attempts)
+	}
+	scheduler.Advance(time.Second)
+	if attempts != 2 {
+		t.Errorf("Expect `attempts` to equal 2, got %v", attempts)
+	}
+	scheduler.Advance(2 * time.Second)
+	if attempts != 3 {
+		t.Errorf("Expect `attempts` to equal 3, got %v", attempts)
+	}
+	if completed != true {
+		t.Error("Expect `completed` to be true")
+	}
+}
+
+type testError struct {
+	code int
+}
+
+func (err *testError) Error() string {
+	return fmt.Sprintf("test error %d", err.code)
+}
+
+func TestCatch(t *testing.T) {
+	signal := NewValuesSignal([]interface{}{1, 2}).ConcatWith(NewErrorSignal(errors.New("failed")))
+	result := make([]int, 0)
+	expected := []int{1, 2, 3, 4}
+
+	signal.Catch(func(err error) Signal {
+		return NewValuesSignal([]interface{}{3, 4})
+	}).SubscribeAuto(func(v int) {
+		result = append(result, v)
+	})
+
+	if len(result) != len(expected) {
+		t.Fatalf("Expecting `len(result)` to equal %v got %v", len(expected), len(result))
+	}
+	for i, v := range expected {
+		if v != result[i] {
+			t.Fatalf("Expecting %v to equal %v", result, expected)
+		}
+	}
+}
+
+func TestCatchAutoShouldOnlyHandleMatchingErrors(t *testing.T) {
+	wrapped := fmt.Errorf("wrapped: %w", &testError{42})
+	var code int
+	var err error
+
+	NewErrorSignal(wrapped).CatchAuto(func(e *testError) Signal {
+		return NewSingleSignal(e.code)
+	}).SubscribeAuto(func(v int) {
+		code = v
+	})
+	if code != 42 {
+		t.Errorf("Expect `code` to equal 42, got %v", code)
+	}
+
+	NewErrorSignal(errors.New("other")).CatchAuto(func(e *testError) Signal {
+		return NewSingleSignal(e.code)
+	}).SubscribeAuto(func(e error) {
+		err = e
+	})
+	if err == nil || err.Error() != "other" {
+		t.Errorf("Expect `err` to be forwarded, got %v", err)
+	}
+}
+
+func TestOnErrorReturn(t *testing.T) {
+	result := make([]int, 0)
+	expected := []int{1, -1}
+	completed := false
+
+	NewSingleSignal(1).ConcatWith(NewErrorSignal(&testError{1})).OnErrorReturnAuto(func(e *testError) int {
+		return -e.code
+	}).SubscribeAuto(func(v int) {
+		result = append(result, v)
+	}, func() {
+		completed = true
+	})
+
+	if completed != true {
+		t.Error("Expect `completed` to be true")
+	}
+	if len(result) != len(expected) {
+		t.Fatalf("Expecting `len(result)` to equal %v got %v", len(expected), len(result))
+	}
+	for i, v := range expected {
+		if v != result[i] {
+			t.Fatalf("Expecting %v to equal %v", result, expected)
+		}
+	}
+}
+
+func TestOnErrorResumeNext(t *testing.T) {
+	result := make([]int, 0)
+	expected := []int{1, 2}
+
+	NewErrorSignal(errors.New("failed")).OnErrorResumeNext(NewValuesSignal([]interface{}{1, 2})).SubscribeAuto(func(v int) {
+		result = append(result, v)
+	})
+
+	if len(result) != len(expected) {
+		t.Fatalf("Expecting `len(result)` to equal %v got %v", len(expected), len(result))
+	}
+	for i, v := range expected {
+		if v != result[i] {
+			t.Fatalf("Expecting %v to equal %v", result, expected)
+		}
+	}
+}
+
+func TestTimeoutShouldErrorWhenNoValueArrivesInTime(t *testing.T) {
+	scheduler := NewTestScheduler(time.Now())
+	var source Subscriber
+	result := make([]int, 0)
+	expected := []int{1, 2}
+	var err error
+
+	NewSignal(func(s Subscriber) {
+		source = s
+	}).Timeout(time.Second, scheduler).SubscribeAuto(func(v int) {
+		result = append(result, v)
+	}, func(e error) {
+		err = e
+	})
+
+	scheduler.Advance(500 * time.Millisecond)
+	source.OnNext(1)
+	scheduler.Advance(900 * time.Millisecond)
+	source.OnNext(2)
+	scheduler.Advance(900 * time.Millisecond)
+	if err != nil {
+		t.Fatalf("Expect `err` to be nil, got %v", err)
+	}
+
+	scheduler.Advance(100 * time.Millisecond)
+	source.OnNext(3)
+	if err != ErrTimeout {
+		t.Errorf("Expect `err` to equal ErrTimeout, got %v", err)
+	}
+	if source.Disposable().IsDisposed() != true {
+		t.Error("Expect `source.Disposable().IsDisposed()` to be true")
+	}
+	if len(result) != len(expected) {
+		t.Fatalf("Expecting `len(result)` to equal %v got %v", len(expected), len(result))
+	}
+	for i, v := range expected {
+		if v != result[i] {
+			t.Fatalf("Expecting %v to equal %v", result, expected)
+		}
+	}
+}
+
+func TestTimeoutTotal(t *testing.T) {
+	scheduler := NewTestScheduler(time.Now())
+	var source Subscriber
+	var err error
+
+	NewSignal(func(s Subscriber) {
+		source = s
+	}).TimeoutTotal(time.Second, scheduler).SubscribeAuto(func(e error) {
+		err = e
+	})
+
+	scheduler.Advance(500 * time.Millisecond)
+	source.OnNext(1)
+	scheduler.Advance(500 * time.Millisecond)
+	if err != ErrTimeout {
+		t.Errorf("Expect `err` to equal ErrTimeout, got %v", err)
+	}
+}
+
+func TestTimeoutWith(t *testing.T) {
+	scheduler := NewTestScheduler(time.Now())
+	result := make([]int, 0)
+	expected := []int{1, 2}
+	completed := false
+
+	NewNeverSignal().TimeoutWith(time.Second, NewValuesSignal([]interface{}{1, 2}), scheduler).SubscribeAuto(func(v int) {
+		result = append(result, v)
+	}, func() {
+		completed = true
+	})
+
+	scheduler.Advance(time.Second)
+	if completed != true {
+		t.Error("Expect `completed` to be true")
+	}
+	if len(result) != len(expected) {
+		t.Fatalf("Expecting `len(result)` to equal %v got %v", len(expected), len(result))
+	}
+	for i, v := range expected {
+		if v != result[i] {
+			t.Fatalf("Expecting %v to equal %v", result, expected)
+		}
+	}
+}
+
+func TestDelay(t *testing.T) {
+	scheduler := NewTestScheduler(time.Now())
+	var source Subscriber
+	result := make([]int, 0)
+	expected := []int{1, 2, 3}
+	completed := false
+
+	NewSignal(func(s Subscriber) {
+		source = s
+	}).Delay(time.Second, scheduler).SubscribeAuto(func(v int) {
+		result = append(result, v)
+	}, func() {
+		completed = true
+	})
+
+	source.OnNext(1)
+	scheduler.Advance(500 * time.Millisecond)
+	source.OnNext(2)
+	source.OnNext(3)
+	source.OnCompleted()
+	if len(result) != 0 {
+		t.Fatalf("Expecting `len(result)` to equal 0 got %v", len(result))
+	}
+
+	scheduler.Advance(500 * time.Millisecond)
+	if len(result) != 1 {
+		t.Fatalf("Expecting `len(result)` to equal 1 got %v", len(result))
+	}
+	if completed != false {
+		t.Error("Expect `completed` to be false")
+	}
+
+	scheduler.Advance(500 * time.Millisecond)
+	if completed != true {
+		t.Error("Expect `completed` to be true")
+	}
+	if len(result) != len(expected) {
+		t.Fatalf("Expecting `len(result)` to equal %v got %v", len(expected), len(result))
+	}
+	for i, v := range expected {
+		if v != result[i] {
+			t.Fatalf("Expecting %v to equal %v", result, expected)
+		}
+	}
+}
+
+func TestDelayShouldForwardErrorsImmediately(t *testing.T) {
+	scheduler := NewTestScheduler(time.Now())
+	result := make([]int, 0)
+	var err error
+
+	NewSingleSignal(1).ConcatWith(NewErrorSignal(errors.New("failed"))).Delay(time.Second, scheduler).SubscribeAuto(func(v int) {
+		result = append(result, v)
+	}, func(e error) {
+		err = e
+	})
+
+	if err == nil {
+		t.Error("Expect `err` not to be nil")
+	}
+	scheduler.Run()
+	if len(result) != 0 {
+		t.Fatalf("Expecting `len(result)` to equal 0 got %v", len(result))
+	}
+}
+
+func TestDelaySubscription(t *testing.T) {
+	scheduler := NewTestScheduler(time.Now())
+	subscribed := false
+
+	disposable := NewSignal(func(s Subscriber) {
+		subscribed = true
+	}).DelaySubscription(time.Second, scheduler).SubscribeAuto()
+
+	scheduler.Advance(500 * time.Millisecond)
+	if subscribed != false {
+		t.Error("Expect `subscribed` to be false")
+	}
+	scheduler.Advance(500 * time.Millisecond)
+	if subscribed != true {
+		t.Error("Expect `subscribed` to be true")
+	}
+
+	subscribed = false
+	NewSignal(func(s Subscriber) {
+		subscribed = true
+	}).DelaySubscription(time.Second, scheduler).SubscribeAuto().Dispose()
+	scheduler.Run()
+	if subscribed != false {
+		t.Error("Expect `subscribed` to be false after disposal")
+	}
+	disposable.Dispose()
+}
+
+func TestDo(t *testing.T) {
+	signal := NewValuesSignal([]interface{}{1, 2, 3})
+	seen := make([]int, 0)
+	result := make([]int, 0)
+	expected := []int{1, 2, 3}
+	completed := false
+
+	signal.Do(func(v T) {
+		seen = append(seen, v.(int))
+	}, nil, func() {
+		completed = true
+	}).SubscribeAuto(func(v int) {
+		result = append(result, v)
+	})
+
+	if completed != true {
+		t.Error("Expect `completed` to be true")
+	}
+	if len(result) != len(expected) || len(seen) != len(expected) {
+		t.Fatalf("Expecting `len(result)` and `len(seen)` to equal %v got %v and %v", len(expected), len(result), len(seen))
+	}
+	for i, v := range expected {
+		if v != result[i] || v != seen[i] {
+			t.Fatalf("Expecting %v and %v to equal %v", result, seen, expected)
+		}
+	}
+}
+
+func TestOnSubscribe(t *testing.T) {
+	subscriptions := 0
+	signal := NewSingleSignal(1).OnSubscribe(func() {
+		subscriptions++
+	})
+
+	signal.SubscribeAuto()
+	signal.SubscribeAuto()
+
+	if subscriptions != 2 {
+		t.Errorf("Expect `subscriptions` to equal 2, got %v", subscriptions)
+	}
+}
+
+func TestOnDisposeShouldRunUponExternalDisposal(t *testing.T) {
+	didDispose := false
+	disposable := NewNeverSignal().OnDispose(func() {
+		didDispose = true
+	}).SubscribeAuto()
+
+	if didDispose != false {
+		t.Error("Expect `didDispose` to be false")
+	}
+	disposable.Dispose()
+	if didDispose != true {
+		t.Error("Expect `didDispose` to be true")
+	}
+}
+
+func TestFinallyShouldRunAfterTermination(t *testing.T) {
+	events := make([]string, 0)
+
+	NewSingleSignal(1).Finally(func() {
+		events = append(events, "finally")
+	}).SubscribeAuto(func() {
+		events = append(events, "completed")
+	})
+	NewErrorSignal(errors.New("failed")).Finally(func() {
+		events = append(events, "finally")
+	}).SubscribeAuto(func(_ error) {
+		events = append(events, "error")
+	})
+
+	expected := []string{"completed", "finally", "error", "finally"}
+	if len(events) != len(expected) {
+		t.Fatalf("Expecting `len(events)` to equal %v got %v", len(expected), len(events))
+	}
+	for i, v := range expected {
+		if v != events[i] {
+			t.Fatalf("Expecting %v to equal %v", events, expected)
+		}
+	}
+}
+
+func TestMaterialize(t *testing.T) {
+	err := errors.New("failed")
+	result := make([]Notification, 0)
+	expected := []Notification{
+		NewNextNotification(1),
+		NewNextNotification(2),
+		NewErrorNotification(err),
+	}
+	completed := false
+
+	NewValuesSignal([]interface{}{1, 2}).ConcatWith(NewErrorSignal(err)).Materialize().SubscribeAuto(func(n Notification) {
+		result = append(result, n)
+	}, func() {
+		completed = true
+	})
+
+	if completed != true {
+		t.Error("Expect `completed` to be true")
+	}
+	if len(result) != len(expected) {
+		t.Fatalf("Expecting `len(result)` to equal %v got %v", len(expected), len(result))
+	}
+	for i, v := range expected {
+		if v != result[i] {
+			t.Fatalf("Expecting %v to equal %v", result, expected)
+		}
+	}
+}
+
+func TestDematerialize(t *testing.T) {
+	result := make([]int, 0)
+	expected := []int{1, 2}
+	completed := false
+
+	NewValuesSignal([]interface{}{
+		NewNextNotification(1),
+		NewNextNotification(2),
+		NewCompletedNotification(),
+		NewNextNotification(3),
+	}).Dematerialize().SubscribeAuto(func(v int) {
+		result = append(result, v)
+	}, func() {
+		completed = true
+	})
+
+	if completed != true {
+		t.Error("Expect `completed` to be true")
+	}
+	if len(result) != len(expected) {
+		t.Fatalf("Expecting `len(result)` to equal %v got %v", len(expected), len(result))
+	}
+	for i, v := range expected {
+		if v != result[i] {
+			t.Fatalf("Expecting %v to equal %v", result, expected)
+		}
+	}
+}
+
+func TestCount(t *testing.T) {
+	var count int
+
+	NewValuesSignal([]interface{}{"a", "b", "c"}).Count().SubscribeAuto(func(v int) {
+		count = v
+	})
+
+	if count != 3 {
+		t.Errorf("Expect `count` to equal 3, got %v", count)
+	}
+}
+
+func TestSum(t *testing.T) {
+	var intSum int
+	var floatSum float64
+
+	NewValuesSignal([]interface{}{1, 2, 3}).Sum().SubscribeAuto(func(v int) {
+		intSum = v
+	})
+	NewValuesSignal([]interface{}{1, 2.5, uint8(3)}).Sum().SubscribeAuto(func(v float64) {
+		floatSum = v
+	})
+
+	if intSum != 6 {
+		t.Errorf("Expect `intSum` to equal 6, got %v", intSum)
+	}
+	if floatSum != 6.5 {
+		t.Errorf("Expect `floatSum` to equal 6.5, got %v", floatSum)
+	}
+}
+
+func TestSumShouldErrorOnNonNumericValues(t *testing.T) {
+	var err error
+
+	NewValuesSignal([]interface{}{1, "2"}).Sum().SubscribeAuto(func(e error) {
+		err = e
+	})
+
+	if err == nil {
+		t.Error("Expect `err` not to be nil")
+	}
+}
+
+func TestAggregatesShouldErrorOnFirstInvalidValue(t *testing.T) {
+	for name, aggregate := range map[string]func(Signal) Signal{
+		"Sum":   func(s Signal) Signal { return s.Sum() },
+		"Min":   func(s Signal) Signal { return s.Min() },
+		"SumOf": SumOf[int],
+	} {
+		var source Subscriber
+		var err error
+		aggregate(NewSignal(func(s Subscriber) {
+			source = s
+		})).SubscribeAuto(func(e error) {
+			err = e
+		})
+
+		source.OnNext(1)
+		source.OnNext("x")
+
+		if err == nil {
+			t.Errorf("Expect %v to error without waiting for completion", name)
+		}
+		if source.Disposable().IsDisposed() != true {
+			t.Errorf("Expect %v to dispose of its source", name)
+		}
+	}
+}
+
+func TestMinAndMax(t *testing.T) {
+	signal := NewValuesSignal([]interface{}{3, 1, 4, 1, 5, 9, 2, 6})
+	var min, max int
+	var minString string
+
+	signal.Min().SubscribeAuto(func(v int) {
+		min = v
+	})
+	signal.Max().SubscribeAuto(func(v int) {
+		max = v
+	})
+	NewValuesSignal([]interface{}{"b", "a", "c"}).Min().SubscribeAuto(func(v string) {
+		minString = v
+	})
+
+	if min != 1 {
+		t.Errorf("Expect `min` to equal 1, got %v", min)
+	}
+	if max != 9 {
+		t.Errorf("Expect `max` to equal 9, got %v", max)
+	}
+	if minString != "a" {
+		t.Errorf("Expect `minString` to equal \"a\", got %v", minString)
+	}
+}
+
+func TestAverage(t *testing.T) {
+	var average float64
+	completed := false
+	sent := false
+
+	NewValuesSignal([]interface{}{1, 2, 3, 4}).Average().SubscribeAuto(func(v float64) {
+		average = v
+	})
+	NewEmptySignal().Average().SubscribeAuto(func(v T) {
+		sent = true
+	}, func() {
+		completed = true
+	})
+
+	if average != 2.5 {
+		t.Errorf("Expect `average` to equal 2.5, got %v", average)
+	}
+	if sent != false || completed != true {
+		t.Error("Expect average of no values to complete without sending a value")
+	}
+}
+
+func TestTypedAggregates(t *testing.T) {
+	signal := NewValuesSignal([]interface{}{3, 1, 4, 1, 5, 9, 2, 6})
+	var sum, min, max int
+	var average float64
+	var minString string
+	var err error
+
+	SumOf[int](signal).SubscribeAuto(func(v int) {
+		sum = v
+	})
+	MinOf[int](signal).SubscribeAuto(func(v int) {
+		min = v
+	})
+	MaxOf[int](signal).SubscribeAuto(func(v int) {
+		max = v
+	})
+	AverageOf[int](NewValuesSignal([]interface{}{1, 2, 3, 4})).SubscribeAuto(func(v float64) {
+		average = v
+	})
+	MinOf[string](NewValuesSignal([]interface{}{"b", "a", "c"})).SubscribeAuto(func(v string) {
+		minString = v
+	})
+	SumOf[int](NewValuesSignal([]interface{}{1, 2.5})).SubscribeAuto(func(e error) {
+		err = e
+	})
+
+	if sum != 31 {
+		t.Errorf("Expect `sum` to equal 31, got %v", sum)
+	}
+	if min != 1 || max != 9 {
+		t.Errorf("Expect `min` and `max` to equal 1 and 9, got %v and %v", min, max)
+	}
+	if average != 2.5 {
+		t.Errorf("Expect `average` to equal 2.5, got %v", average)
+	}
+	if minString != "a" {
+		t.Errorf("Expect `minString` to equal \"a\", got %v", minString)
+	}
+	if err == nil {
+		t.Error("Expect `err` not to be nil")
+	}
+}
+
+func TestAllAndAny(t *testing.T) {
+	signal := NewValuesSignal([]interface{}{2, 4, 6})
+	var all, any, none bool
+
+	signal.AllAuto(func(v int) bool {
+		return v%2 == 0
+	}).SubscribeAuto(func(v bool) {
+		all = v
+	})
+	signal.AnyAuto(func(v int) bool {
+		return v > 5
+	}).SubscribeAuto(func(v bool) {
+		any = v
+	})
+	signal.AnyAuto(func(v int) bool {
+		return v > 6
+	}).SubscribeAuto(func(v bool) {
+		none = v
+	})
+
+	if all != true {
+		t.Error("Expect `all` to be true")
+	}
+	if any != true {
+		t.Error("Expect `any` to be true")
+	}
+	if none != false {
+		t.Error("Expect `none` to be false")
+	}
+}
+
+func TestAnyShouldDisposeOfSourceOnceDecided(t *testing.T) {
+	var source Subscriber
+	results := make([]bool, 0)
+
+	NewSignal(func(s Subscriber) {
+		source = s
+	}).Contains(2).SubscribeAuto(func(v bool) {
+		results = append(results, v)
+	})
+
+	source.OnNext(1)
+	if len(results) != 0 {
+		t.Fatalf("Expecting `len(results)` to equal 0 got %v", len(results))
+	}
+	source.OnNext(2)
+	if len(results) != 1 || results[0] != true {
+		t.Fatalf("Expecting `results` to equal [true] got %v", results)
+	}
+	if source.Disposable().IsDisposed() != true {
+		t.Error("Expect `source.Disposable().IsDisposed()` to be true")
+	}
+}
+
+func TestIsEmpty(t *testing.T) {
+	var empty, notEmpty bool
+
+	NewEmptySignal().IsEmpty().SubscribeAuto(func(v bool) {
+		empty = v
+	})
+	NewSingleSignal(1).IsEmpty().SubscribeAuto(func(v bool) {
+		notEmpty = !v
+	})
+
+	if empty != true {
+		t.Error("Expect `empty` to be true")
+	}
+	if notEmpty != true {
+		t.Error("Expect `notEmpty` to be true")
+	}
+}
+
+func TestSequenceEqual(t *testing.T) {
+	signal := NewValuesSignal([]interface{}{1, 2, 3})
+	results := make([]bool, 0)
+	expected := []bool{true, false, false}
+
+	for _, other := range []Signal{
+		NewValuesSignal([]interface{}{1, 2, 3}),
+		NewValuesSignal([]interface{}{1, 2}),
+		NewValuesSignal([]interface{}{1, 5, 3}),
+	} {
+		signal.SequenceEqual(other, nil).SubscribeAuto(func(v bool) {
+			results = append(results, v)
+		})
+	}
+
+	if len(results) != len(expected) {
+		t.Fatalf("Expecting `len(results)` to equal %v got %v", len(expected), len(results))
+	}
+	for i, v := range expected {
+		if v != results[i] {
+			t.Fatalf("Expecting %v to equal %v", results, expected)
+		}
+	}
+}
+
+func TestSequenceEqualShouldNotSubscribeToOtherOnceDecided(t *testing.T) {
+	subscribed := false
+	other := NewSignal(func(_ Subscriber) {
+		subscribed = true
+	})
+	var err error
+
+	NewErrorSignal(errors.New("failed")).SequenceEqual(other, nil).SubscribeAuto(func(e error) {
+		err = e
+	})
+
+	if err == nil {
+		t.Error("Expect `err` not to be nil")
+	}
+	if subscribed != false {
+		t.Error("Expect `other` not to be subscribed to")
+	}
+}
+
+func TestFirstAndLast(t *testing.T) {
+	signal := NewValuesSignal([]interface{}{1, 2, 3})
+	var first, last int
+	var firstErr, lastErr error
+
+	signal.First().SubscribeAuto(func(v int) {
+		first = v
+	})
+	signal.Last().SubscribeAuto(func(v int) {
+		last = v
+	})
+	NewEmptySignal().First().SubscribeAuto(func(e error) {
+		firstErr = e
+	})
+	NewEmptySignal().Last().SubscribeAuto(func(e error) {
+		lastErr = e
+	})
+
+	if first != 1 {
+		t.Errorf("Expect `first` to equal 1, got %v", first)
+	}
+	if last != 3 {
+		t.Errorf("Expect `last` to equal 3, got %v", last)
+	}
+	if firstErr != ErrNoElements {
+		t.Errorf("Expect `firstErr` to equal ErrNoElements, got %v", firstErr)
+	}
+	if lastErr != ErrNoElements {
+		t.Errorf("Expect `lastErr` to equal ErrNoElements, got %v", lastErr)
+	}
+}
+
+func TestFirstAndLastOrDefault(t *testing.T) {
+	var first, last int
+
+	NewEmptySignal().FirstOrDefault(-1).SubscribeAuto(func(v int) {
+		first = v
+	})
+	NewEmptySignal().LastOrDefault(-2).SubscribeAuto(func(v int) {
+		last = v
+	})
+
+	if first != -1 {
+		t.Errorf("Expect `first` to equal -1, got %v", first)
+	}
+	if last != -2 {
+		t.Errorf("Expect `last` to equal -2, got %v", last)
+	}
+}
+
+func TestElementAt(t *testing.T) {
+	signal := NewValuesSignal([]interface{}{1, 2, 3})
+	var element int
+	var err error
+
+	signal.ElementAt(1).SubscribeAuto(func(v int) {
+		element = v
+	})
+	signal.ElementAt(3).SubscribeAuto(func(e error) {
+		err = e
+	})
+
+	if element != 2 {
+		t.Errorf("Expect `element` to equal 2, got %v", element)
+	}
+	if err != ErrNoElements {
+		t.Errorf("Expect `err` to equal ErrNoElements, got %v", err)
+	}
+}
+
+func TestSwitchIfEmpty(t *testing.T) {
+	result := make([]int, 0)
+	expected := []int{1, 2, 3}
+
+	NewEmptySignal().SwitchIfEmpty(NewValuesSignal([]interface{}{1, 2})).SubscribeAuto(func(v int) {
+		result = append(result, v)
+	})
+	NewSingleSignal(3).SwitchIfEmpty(NewValuesSignal([]interface{}{4, 5})).SubscribeAuto(func(v int) {
+		result = append(result, v)
+	})
+
+	if len(result) != len(expected) {
+		t.Fatalf("Expecting `len(result)` to equal %v got %v", len(expected), len(result))
+	}
+	for i, v := range expected {
+		if v != result[i] {
+			t.Fatalf("Expecting %v to equal %v", result, expected)
+		}
+	}
+}
+
+func TestStartWithAndEndWith(t *testing.T) {
+	result := make([]int, 0)
+	expected := []int{1, 2, 3, 4, 5}
+	completed := false
+
+	NewSingleSignal(3).StartWith(1, 2).EndWith(4, 5).SubscribeAuto(func(v int) {
+		result = append(result, v)
+	}, func() {
+		completed = true
+	})
+
+	if completed != true {
+		t.Error("Expect `completed` to be true")
+	}
+	if len(result) != len(expected) {
+		t.Fatalf("Expecting `len(result)` to equal %v got %v", len(expected), len(result))
+	}
+	for i, v := range expected {
+		if v != result[i] {
+			t.Fatalf("Expecting %v to equal %v", result, expected)
+		}
+	}
+}
+
+func TestRace(t *testing.T) {
+	scheduler := NewTestScheduler(time.Now())
+	slowDisposed := false
+	result := make([]string, 0)
+	expected := []string{"fast 1", "fast 2"}
+
+	Race(
+		NewValuesSignal([]interface{}{"slow 1", "slow 2"}).Delay(2*time.Second, scheduler).OnDispose(func() {
+			slowDisposed = true
+		}),
+		NewValuesSignal([]interface{}{"fast 1", "fast 2"}).Delay(time.Second, scheduler),
+	).SubscribeAuto(func(v string) {
+		result = append(result, v)
+	})
+
+	scheduler.Advance(time.Second)
+	if slowDisposed != true {
+		t.Error("Expect `slowDisposed` to be true")
+	}
+
+	scheduler.Run()
+	if len(result) != len(expected) {
+		t.Fatalf("Expecting `len(result)` to equal %v got %v", len(expected), len(result))
+	}
+	for i, v := range expected {
+		if v != result[i] {
+			t.Fatalf("Expecting %v to equal %v", result, expected)
+		}
+	}
+}
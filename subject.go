@@ -0,0 +1,336 @@
+package main
+
+import "time"
+
+// A Subject is both a Subscriber and a Signal: events sent to it are
+// forwarded to all of its current subscribers.
+//
+// Unlike signals created with NewSignal, subscribing to a subject does not
+// trigger any side effect, which makes subjects the building block for
+// sharing a single stream of events among several subscribers.
+//
+// Subjects can be used from several goroutines, and events can be sent to
+// them concurrently: they are delivered one at a time, in the order the
+// subject received them.
+type Subject interface {
+	Subscriber
+	Signal
+}
+
+// A subject that also holds the latest value sent to it.
+type BehaviorSubject interface {
+	Subject
+	Value() T
+}
+
+type subjectKind int
+
+const (
+	publishSubjectKind subjectKind = iota
+	behaviorSubjectKind
+	replaySubjectKind
+	asyncSubjectKind
+)
+
+type timedValue struct {
+	value T
+	time  time.Time
+}
+
+// A subscriber of a subject.
+//
+// While `replaying` is true, the subscriber is still receiving the values
+// the subject replays upon subscription, and live events are queued in
+// `pending` instead of being sent right away. Both fields are only accessed
+// while holding the subject's state.
+type subjectObserver struct {
+	subscriber Subscriber
+	replaying  bool
+	pending    []Notification
+}
+
+type subjectState struct {
+	observers []*subjectObserver
+	buffer    []timedValue
+	terminal  *Notification
+}
+
+type subject struct {
+	Signal
+	kind       subjectKind
+	size       int
+	window     time.Duration
+	scheduler  Scheduler
+	state      Atomic
+	disposable CompositeDisposable
+	// Serializes the events sent to the subject.
+	receiver Subscriber
+}
+
+// Receives the events sent to a subject, one at a time.
+type subjectReceiver struct {
+	subject *subject
+}
+
+func (r subjectReceiver) OnNext(value T) {
+	r.subject.onNext(value)
+}
+
+func (r subjectReceiver) OnError(err error) {
+	r.subject.onError(err)
+}
+
+func (r subjectReceiver) OnCompleted() {
+	r.subject.onCompleted()
+}
+
+func (r subjectReceiver) Disposable() CompositeDisposable {
+	return r.subject.disposable
+}
+
+func newSubject(kind subjectKind, size int, window time.Duration, scheduler Scheduler) *subject {
+	s := &subject{
+		kind:       kind,
+		size:       size,
+		window:     window,
+		scheduler:  scheduler,
+		state:      NewAtomic(subjectState{}),
+		disposable: NewCompositeDisposable(nil),
+	}
+	s.Signal = NewSignal(s.subscribe)
+	s.receiver = NewSerializedSubscriber(subjectReceiver{s})
+	return s
+}
+
+// Creates a subject that forwards events to its subscribers as they arrive,
+// without replaying anything to new subscribers except the terminal event.
+func NewPublishSubject() Subject {
+	return newSubject(publishSubjectKind, 0, 0, nil)
+}
+
+// Creates a subject that sends its latest value, starting with `initial`,
+// to each new subscriber, then forwards events as they arrive.
+func NewBehaviorSubject(initial T) BehaviorSubject {
+	s := newSubject(behaviorSubjectKind, 1, 0, nil)
+	s.state.SetValue(subjectState{buffer: []timedValue{{value: initial}}})
+	return s
+}
+
+// Creates a subject that replays the values sent to it to each new
+// subscriber, then forwards events as they arrive.
+//
+// At most the `size` latest values are replayed, and only those sent less
+// than `window` ago according to `scheduler`. A `size` or `window` <= 0
+// means that no such bound applies, in which case `scheduler` may be nil.
+func NewReplaySubject(size int, window time.Duration, scheduler Scheduler) Subject {
+	if window > 0 && scheduler == nil {
+		panic("NewReplaySubject: a scheduler is required for time-bounded buffers")
+	}
+	return newSubject(replaySubjectKind, size, window, scheduler)
+}
+
+// Creates a subject that only sends the last value sent to it, followed by
+// completion, once it completes.
+func NewAsyncSubject() Subject {
+	return newSubject(asyncSubjectKind, 1, 0, nil)
+}
+
+func (subject *subject) now() time.Time {
+	if subject.scheduler == nil {
+		return time.Time{}
+	}
+	return subject.scheduler.Now()
+}
+
+// Returns the buffered values that are still in the time window.
+func (subject *subject) trim(buffer []timedValue) []timedValue {
+	if subject.window <= 0 {
+		return buffer
+	}
+	limit := subject.now().Add(-subject.window)
+	for len(buffer) > 0 && buffer[0].time.Before(limit) {
+		buffer = buffer[1:]
+	}
+	return buffer
+}
+
+// Returns the notifications to send to a new subscriber.
+func (subject *subject) replay(state subjectState) []Notification {
+	notifications := make([]Notification, 0, len(state.buffer)+1)
+	switch subject.kind {
+	case behaviorSubjectKind:
+		if state.terminal == nil {
+			notifications = append(notifications, NewNextNotification(state.buffer[0].value))
+		}
+	case replaySubjectKind:
+		for _, v := range subject.trim(state.buffer) {
+			notifications = append(notifications, NewNextNotification(v.value))
+		}
+	case asyncSubjectKind:
+		if state.terminal != nil && state.terminal.Kind == NotificationCompleted && len(state.buffer) > 0 {
+			notifications = append(notifications, NewNextNotification(state.buffer[0].value))
+		}
+	}
+	if state.terminal != nil {
+		notifications = append(notifications, *state.terminal)
+	}
+	return notifications
+}
+
+// Sends the given notifications to every observer, or queues them for the
+// observers still replaying.
+//
+// If `terminal` is not nil, the subject terminates with it.
+func (subject *subject) send(update func(subjectState) []timedValue, notifications []Notification, terminal *Notification) {
+	_, targets := subject.state.ModifyData(func(s interface{}) (interface{}, interface{}) {
+		state := s.(subjectState)
+		if state.terminal != nil {
+			return state, nil
+		}
+
+		buffer := state.buffer
+		if update != nil {
+			buffer = update(state)
+		}
+		if terminal != nil {
+			// An async subject sends its last value upon completion, which
+			// is read here so that it cannot change in the meantime.
+			if subject.kind == asyncSubjectKind && terminal.Kind == NotificationCompleted && len(buffer) > 0 {
+				notifications = append(notifications, NewNextNotification(buffer[0].value))
+			}
+			notifications = append(notifications, *terminal)
+		}
+
+		live := make([]Subscriber, 0, len(state.observers))
+		for _, o := range state.observers {
+			if o.replaying {
+				o.pending = append(o.pending, notifications...)
+			} else {
+				live = append(live, o.subscriber)
+			}
+		}
+
+		observers := state.observers
+		if terminal != nil {
+			observers = nil
+		}
+		return subjectState{observers, buffer, terminal}, live
+	})
+	if targets == nil {
+		return
+	}
+
+	for _, s := range targets.([]Subscriber) {
+		for _, n := range notifications {
+			n.Accept(s)
+		}
+	}
+	if terminal != nil {
+		subject.disposable.Dispose()
+	}
+}
+
+func (subject *subject) OnNext(value T) {
+	subject.receiver.OnNext(value)
+}
+
+func (subject *subject) OnError(err error) {
+	subject.receiver.OnError(err)
+}
+
+func (subject *subject) OnCompleted() {
+	subject.receiver.OnCompleted()
+}
+
+func (subject *subject) onNext(value T) {
+	var notifications []Notification
+	if subject.kind != asyncSubjectKind {
+		notifications = []Notification{NewNextNotification(value)}
+	}
+
+	subject.send(func(state subjectState) []timedValue {
+		switch subject.kind {
+		case publishSubjectKind:
+			return nil
+		case behaviorSubjectKind, asyncSubjectKind:
+			return []timedValue{{value: value}}
+		}
+		buffer := append(subject.trim(state.buffer), timedValue{value, subject.now()})
+		if subject.size > 0 && len(buffer) > subject.size {
+			buffer = buffer[len(buffer)-subject.size:]
+		}
+		return buffer
+	}, notifications, nil)
+}
+
+func (subject *subject) onError(err error) {
+	terminal := NewErrorNotification(err)
+	subject.send(nil, nil, &terminal)
+}
+
+func (subject *subject) onCompleted() {
+	terminal := NewCompletedNotification()
+	subject.send(nil, nil, &terminal)
+}
+
+func (subject *subject) Disposable() CompositeDisposable {
+	return subject.disposable
+}
+
+// The latest value sent to the subject.
+func (subject *subject) Value() T {
+	buffer := subject.state.Value().(subjectState).buffer
+	if len(buffer) == 0 {
+		return nil
+	}
+	return buffer[len(buffer)-1].value
+}
+
+func (subject *subject) subscribe(subscriber Subscriber) {
+	observer := &subjectObserver{subscriber: subscriber, replaying: true}
+	_, replay := subject.state.ModifyData(func(s interface{}) (interface{}, interface{}) {
+		state := s.(subjectState)
+		if state.terminal != nil {
+			return state, subject.replay(state)
+		}
+		observers := append(make([]*subjectObserver, 0, len(state.observers)+1), state.observers...)
+		return subjectState{append(observers, observer), state.buffer, nil}, subject.replay(state)
+	})
+
+	for _, n := range replay.([]Notification) {
+		n.Accept(subscriber)
+	}
+
+	// Send the events that arrived while replaying, until there are none left
+	// and the observer can receive events directly.
+	for {
+		_, pending := subject.state.ModifyData(func(s interface{}) (interface{}, interface{}) {
+			p := observer.pending
+			observer.pending = nil
+			if len(p) == 0 {
+				observer.replaying = false
+			}
+			return s, p
+		})
+		if len(pending.([]Notification)) == 0 {
+			break
+		}
+		for _, n := range pending.([]Notification) {
+			n.Accept(subscriber)
+		}
+	}
+
+	subscriber.Disposable().AddDisposableFunc(func() error {
+		subject.state.Modify(func(s interface{}) interface{} {
+			state := s.(subjectState)
+			observers := make([]*subjectObserver, 0, len(state.observers))
+			for _, o := range state.observers {
+				if o != observer {
+					observers = append(observers, o)
+				}
+			}
+			return subjectState{observers, state.buffer, state.terminal}
+		})
+		return nil
+	})
+}
@@ -0,0 +1,266 @@
+package main
+
+import (
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestPublishSubjectShouldForwardEventsToCurrentSubscribers(t *testing.T) {
+	subject := NewPublishSubject()
+	first := make([]int, 0)
+	second := make([]int, 0)
+	completed := false
+
+	subject.SubscribeAuto(func(v int) {
+		first = append(first, v)
+	})
+	subject.OnNext(1)
+	subject.SubscribeAuto(func(v int) {
+		second = append(second, v)
+	})
+	subject.OnNext(2)
+	subject.OnCompleted()
+	subject.OnNext(3)
+	subject.SubscribeAuto(func() {
+		completed = true
+	})
+
+	if len(first) != 2 || first[0] != 1 || first[1] != 2 {
+		t.Errorf("Expect `first` to equal [1 2], got %v", first)
+	}
+	if len(second) != 1 || second[0] != 2 {
+		t.Errorf("Expect `second` to equal [2], got %v", second)
+	}
+	if completed != true {
+		t.Error("Expect late subscriber to receive completion")
+	}
+}
+
+func TestPublishSubjectShouldStopForwardingToDisposedSubscribers(t *testing.T) {
+	subject := NewPublishSubject()
+	result := make([]int, 0)
+
+	disposable := subject.SubscribeAuto(func(v int) {
+		result = append(result, v)
+	})
+	subject.OnNext(1)
+	disposable.Dispose()
+	subject.OnNext(2)
+
+	if len(result) != 1 {
+		t.Errorf("Expect `result` to equal [1], got %v", result)
+	}
+}
+
+func TestBehaviorSubjectShouldSendCurrentValueToNewSubscribers(t *testing.T) {
+	subject := NewBehaviorSubject(0)
+	result := make([]int, 0)
+	expected := []int{0, 1, 2, 2, 3}
+
+	subject.SubscribeAuto(func(v int) {
+		result = append(result, v)
+	})
+	subject.OnNext(1)
+	subject.OnNext(2)
+	subject.SubscribeAuto(func(v int) {
+		result = append(result, v)
+	})
+	subject.Map(func(v T) U {
+		return v.(int) + 1
+	}).Take(1).SubscribeAuto(func(v int) {
+		result = append(result, v)
+	})
+
+	if subject.Value() != 2 {
+		t.Errorf("Expect `subject.Value()` to equal 2, got %v", subject.Value())
+	}
+	if len(result) != len(expected) {
+		t.Fatalf("Expecting `len(result)` to equal %v got %v", len(expected), len(result))
+	}
+	for i, v := range expected {
+		if v != result[i] {
+			t.Fatalf("Expecting %v to equal %v", result, expected)
+		}
+	}
+}
+
+func TestReplaySubjectShouldReplayBoundedBuffer(t *testing.T) {
+	scheduler := NewTestScheduler(time.Now())
+	subject := NewReplaySubject(3, 2*time.Second, scheduler)
+	result := make([]int, 0)
+	expected := []int{3, 4, 5}
+
+	subject.OnNext(1)
+	scheduler.Advance(time.Second)
+	subject.OnNext(2)
+	subject.OnNext(3)
+	scheduler.Advance(time.Second)
+	subject.OnNext(4)
+	subject.OnNext(5)
+	scheduler.Advance(500 * time.Millisecond)
+	subject.SubscribeAuto(func(v int) {
+		result = append(result, v)
+	})
+
+	if len(result) != len(expected) {
+		t.Fatalf("Expecting `len(result)` to equal %v got %v", len(expected), len(result))
+	}
+	for i, v := range expected {
+		if v != result[i] {
+			t.Fatalf("Expecting %v to equal %v", result, expected)
+		}
+	}
+
+	result = make([]int, 0)
+	scheduler.Advance(time.Second)
+	subject.SubscribeAuto(func(v int) {
+		result = append(result, v)
+	})
+	if len(result) != 2 {
+		t.Errorf("Expect values older than the window to be evicted, got %v", result)
+	}
+}
+
+func TestReplaySubjectShouldReplayTerminalEvent(t *testing.T) {
+	subject := NewReplaySubject(0, 0, nil)
+	result := make([]int, 0)
+	var err error
+
+	subject.OnNext(1)
+	subject.OnError(errors.New("failed"))
+	subject.SubscribeAuto(func(v int) {
+		result = append(result, v)
+	}, func(e error) {
+		err = e
+	})
+
+	if len(result) != 1 || result[0] != 1 {
+		t.Errorf("Expect `result` to equal [1], got %v", result)
+	}
+	if err == nil {
+		t.Error("Expect `err` not to be nil")
+	}
+}
+
+func TestAsyncSubjectShouldOnlySendLastValueOnCompletion(t *testing.T) {
+	subject := NewAsyncSubject()
+	result := make([]int, 0)
+
+	subject.SubscribeAuto(func(v int) {
+		result = append(result, v)
+	})
+	subject.OnNext(1)
+	subject.OnNext(2)
+	if len(result) != 0 {
+		t.Fatalf("Expecting `len(result)` to equal 0 got %v", len(result))
+	}
+
+	subject.OnCompleted()
+	subject.SubscribeAuto(func(v int) {
+		result = append(result, v)
+	})
+	if len(result) != 2 || result[0] != 2 || result[1] != 2 {
+		t.Errorf("Expect `result` to equal [2 2], got %v", result)
+	}
+}
+
+func TestSubjectShouldBeUsableAsSubscriber(t *testing.T) {
+	subject := NewReplaySubject(0, 0, nil)
+	result := make([]int, 0)
+
+	NewValuesSignal([]interface{}{1, 2, 3}).Subscribe(subject)
+	subject.SubscribeAuto(func(v int) {
+		result = append(result, v)
+	})
+
+	if len(result) != 3 {
+		t.Errorf("Expect `result` to equal [1 2 3], got %v", result)
+	}
+	if subject.Disposable().IsDisposed() != true {
+		t.Error("Expect `subject.Disposable().IsDisposed()` to be true")
+	}
+}
+
+func TestSubjectShouldBeSafeForConcurrentSubscriptions(t *testing.T) {
+	subject := NewReplaySubject(0, 0, nil)
+	done := make(chan int, 10)
+
+	for i := 0; i < 10; i++ {
+		go func() {
+			count := 0
+			subject.SubscribeAuto(func(v int) {
+				count++
+			}, func() {
+				done <- count
+			})
+		}()
+	}
+	for i := 0; i < 100; i++ {
+		subject.OnNext(i)
+	}
+	subject.OnCompleted()
+
+	for i := 0; i < 10; i++ {
+		if count := <-done; count != 100 {
+			t.Errorf("Expect every subscriber to receive 100 values, got %v", count)
+		}
+	}
+}
+
+func TestSubjectShouldBeSafeForConcurrentEvents(t *testing.T) {
+	subject := NewPublishSubject()
+	async := NewAsyncSubject()
+	active := NewAtomic(0)
+	overlapped := NewAtomic(false)
+	count := 0
+	last := NewAtomic(-1)
+	var asyncValue T
+
+	subject.SubscribeAuto(func(v int) {
+		if active.Modify(func(a interface{}) interface{} { return a.(int) + 1 }).(int) != 0 {
+			overlapped.SetValue(true)
+		}
+		count++
+		runtime.Gosched()
+		active.Modify(func(a interface{}) interface{} { return a.(int) - 1 })
+	})
+	async.SubscribeAuto(func(v T) {
+		asyncValue = v
+	})
+
+	start := make(chan struct{})
+	done := make(chan struct{})
+	for i := 0; i < 10; i++ {
+		i := i
+		go func() {
+			<-start
+			for v := 0; v < 1000; v++ {
+				subject.OnNext(v)
+			}
+			// Only the last OnNext before completion may be sent by the
+			// async subject.
+			last.Modify(func(_ interface{}) interface{} {
+				async.OnNext(i)
+				return i
+			})
+			done <- struct{}{}
+		}()
+	}
+	close(start)
+	for i := 0; i < 10; i++ {
+		<-done
+	}
+	async.OnCompleted()
+
+	if overlapped.Value().(bool) {
+		t.Error("Expect values not to be delivered concurrently")
+	}
+	if count != 10000 {
+		t.Errorf("Expect `count` to equal 10000, got %v", count)
+	}
+	if asyncValue != last.Value() {
+		t.Errorf("Expect `asyncValue` to equal %v, got %v", last.Value(), asyncValue)
+	}
+}
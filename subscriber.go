@@ -1,6 +1,23 @@
 package main
 
+import "errors"
+
 // An Subscriber is a receiver of events from an Signal.
+//
+// Subscribers enforce the event grammar `OnNext* (OnError | OnCompleted)?`:
+// events received after a terminal event, or after disposal, are dropped.
+//
+// Events must be sent to a subscriber one at a time: the grammar is checked
+// before calling each callback, so an event sent concurrently may still
+// overlap a terminal event. Signals sending events from several goroutines
+// serialize them, e.g. with NewSerializedSubscriber, as do all the operators
+// and subjects of this package.
+//
+// Subscribers created with NewSubscriber recover from panics in their
+// callbacks: a panic in the `next` callback is sent to the subscriber itself
+// as a PanicError, terminating it. PanicErrors received without an error
+// callback, and panics in the error and completion callbacks, cannot be sent
+// anywhere: they are reported to the unhandled panic handler instead.
 type Subscriber interface {
 	OnNext(T)
 	OnError(error)
@@ -8,31 +25,103 @@ type Subscriber interface {
 	Disposable() CompositeDisposable
 }
 
+type subscriberState int
+
+const (
+	subscriberActive subscriberState = iota
+	subscriberTerminated
+	subscriberDisposed
+)
+
 type subscriber struct {
 	nextFunc   func(T)
 	errFunc    func(error)
 	compFunc   func()
+	state      Atomic
 	disposable CompositeDisposable
 }
 
+var grammarViolationHandler = NewAtomic((func(Subscriber, Notification))(nil))
+
+// Sets a function to be called whenever a subscriber receives an event after
+// a terminal event, which is then dropped. Useful to find misbehaving
+// signals while debugging.
+//
+// Pass nil, the default, to drop such events silently.
+func SetGrammarViolationHandler(handler func(Subscriber, Notification)) {
+	grammarViolationHandler.SetValue(handler)
+}
+
+func (o *subscriber) reportViolation(notification Notification) {
+	if handler := grammarViolationHandler.Value().(func(Subscriber, Notification)); handler != nil {
+		handler(o, notification)
+	}
+}
+
+// Forwards a value, unless the subscriber has terminated or been disposed of.
+//
+// The state is not held while calling the callback, which is why events must
+// not be sent concurrently.
 func (o *subscriber) OnNext(value T) {
-	if o.nextFunc != nil {
-		o.nextFunc(value)
+	switch o.state.Value().(subscriberState) {
+	case subscriberActive:
+		if o.nextFunc != nil {
+			o.next(value)
+		}
+	case subscriberTerminated:
+		o.reportViolation(NewNextNotification(value))
+	}
+}
+
+func (o *subscriber) next(value T) {
+	defer recoverPanic(o.OnError)
+	o.nextFunc(value)
+}
+
+// Reports a panic in a terminal callback, which cannot be sent anywhere.
+func (o *subscriber) reportPanic(err error) {
+	reportUnhandledPanic(err.(*PanicError))
+}
+
+// Atomically moves the subscriber to the terminated state.
+//
+// Returns whether the subscriber was active, reporting a violation if it had
+// already terminated.
+func (o *subscriber) terminate(notification Notification) bool {
+	orig := o.state.Modify(func(s interface{}) interface{} {
+		if s.(subscriberState) == subscriberActive {
+			return subscriberTerminated
+		}
+		return s
+	})
+	if orig.(subscriberState) == subscriberTerminated {
+		o.reportViolation(notification)
 	}
+	return orig.(subscriberState) == subscriberActive
 }
 
 func (o *subscriber) OnError(err error) {
+	if !o.terminate(NewErrorNotification(err)) {
+		return
+	}
+	defer o.disposable.Dispose()
+	defer recoverPanic(o.reportPanic)
 	if o.errFunc != nil {
 		o.errFunc(err)
+	} else if panicErr := (*PanicError)(nil); errors.As(err, &panicErr) {
+		reportUnhandledPanic(panicErr)
 	}
-	o.disposable.Dispose()
 }
 
 func (o *subscriber) OnCompleted() {
+	if !o.terminate(NewCompletedNotification()) {
+		return
+	}
+	defer o.disposable.Dispose()
+	defer recoverPanic(o.reportPanic)
 	if o.compFunc != nil {
 		o.compFunc()
 	}
-	o.disposable.Dispose()
 }
 
 func (o *subscriber) Disposable() CompositeDisposable {
@@ -44,12 +133,80 @@ func NewSubscriber(next func(T), err func(error), completed func()) Subscriber {
 		nextFunc: next,
 		errFunc:  err,
 		compFunc: completed,
+		state:    NewAtomic(subscriberActive),
 	}
 	subscriber.disposable = NewCompositeDisposable(func() error {
-		subscriber.nextFunc = nil
-		subscriber.errFunc = nil
-		subscriber.compFunc = nil
+		subscriber.state.Modify(func(s interface{}) interface{} {
+			if s.(subscriberState) == subscriberActive {
+				return subscriberDisposed
+			}
+			return s
+		})
 		return nil
 	})
 	return subscriber
 }
+
+// A subscriber that forwards events to another one, ensuring that they are
+// never delivered concurrently.
+//
+// Events sent while another one is being delivered, either from another
+// goroutine or re-entrantly from the same one, are queued and delivered by
+// the goroutine already delivering, once it is done. No lock is held while
+// delivering events.
+type serializedSubscriber struct {
+	subscriber Subscriber
+	state      Atomic
+}
+
+type serializedState struct {
+	emitting bool
+	queue    []Notification
+}
+
+func NewSerializedSubscriber(subscriber Subscriber) Subscriber {
+	return &serializedSubscriber{subscriber, NewAtomic(serializedState{})}
+}
+
+func (o *serializedSubscriber) send(notification Notification) {
+	_, emit := o.state.ModifyData(func(s interface{}) (interface{}, interface{}) {
+		state := s.(serializedState)
+		if state.emitting {
+			return serializedState{true, append(state.queue, notification)}, false
+		}
+		return serializedState{true, nil}, true
+	})
+	if emit == false {
+		return
+	}
+
+	notification.Accept(o.subscriber)
+	for {
+		_, queue := o.state.ModifyData(func(s interface{}) (interface{}, interface{}) {
+			state := s.(serializedState)
+			return serializedState{len(state.queue) > 0, nil}, state.queue
+		})
+		if len(queue.([]Notification)) == 0 {
+			return
+		}
+		for _, n := range queue.([]Notification) {
+			n.Accept(o.subscriber)
+		}
+	}
+}
+
+func (o *serializedSubscriber) OnNext(value T) {
+	o.send(NewNextNotification(value))
+}
+
+func (o *serializedSubscriber) OnError(err error) {
+	o.send(NewErrorNotification(err))
+}
+
+func (o *serializedSubscriber) OnCompleted() {
+	o.send(NewCompletedNotification())
+}
+
+func (o *serializedSubscriber) Disposable() CompositeDisposable {
+	return o.subscriber.Disposable()
+}
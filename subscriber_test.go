@@ -0,0 +1,184 @@
+package main
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSubscriberShouldDropEventsAfterTermination(t *testing.T) {
+	result := make([]int, 0)
+	completions := 0
+	errs := 0
+	subscriber := NewSubscriber(func(v T) {
+		result = append(result, v.(int))
+	}, func(_ error) {
+		errs++
+	}, func() {
+		completions++
+	})
+
+	subscriber.OnNext(1)
+	subscriber.OnCompleted()
+	subscriber.OnNext(2)
+	subscriber.OnCompleted()
+	subscriber.OnError(errors.New("failed"))
+
+	if len(result) != 1 {
+		t.Errorf("Expect `result` to equal [1], got %v", result)
+	}
+	if completions != 1 {
+		t.Errorf("Expect `completions` to equal 1, got %v", completions)
+	}
+	if errs != 0 {
+		t.Errorf("Expect `errs` to equal 0, got %v", errs)
+	}
+}
+
+func TestSubscriberShouldDropEventsAfterDisposal(t *testing.T) {
+	result := make([]int, 0)
+	completed := false
+	subscriber := NewSubscriber(func(v T) {
+		result = append(result, v.(int))
+	}, nil, func() {
+		completed = true
+	})
+
+	subscriber.Disposable().Dispose()
+	subscriber.OnNext(1)
+	subscriber.OnCompleted()
+
+	if len(result) != 0 {
+		t.Errorf("Expect `result` to be empty, got %v", result)
+	}
+	if completed != false {
+		t.Error("Expect `completed` to be false")
+	}
+}
+
+func TestSubscriberShouldReportGrammarViolations(t *testing.T) {
+	violations := make([]Notification, 0)
+	SetGrammarViolationHandler(func(_ Subscriber, n Notification) {
+		violations = append(violations, n)
+	})
+	defer SetGrammarViolationHandler(nil)
+
+	subscriber := NewSubscriber(nil, nil, nil)
+	subscriber.OnCompleted()
+	subscriber.OnNext(1)
+	subscriber.OnCompleted()
+
+	disposed := NewSubscriber(nil, nil, nil)
+	disposed.Disposable().Dispose()
+	disposed.OnNext(1)
+
+	if len(violations) != 2 {
+		t.Fatalf("Expecting `len(violations)` to equal 2 got %v", len(violations))
+	}
+	if violations[0] != NewNextNotification(1) || violations[1] != NewCompletedNotification() {
+		t.Errorf("Expect `violations` to equal [Next(1) Completed], got %v", violations)
+	}
+}
+
+func TestSerializedSubscriberShouldQueueReentrantEvents(t *testing.T) {
+	result := make([]int, 0)
+	expected := []int{1, 2, 3}
+	var serialized Subscriber
+	serialized = NewSerializedSubscriber(NewSubscriber(func(v T) {
+		result = append(result, v.(int))
+		if v.(int) == 1 {
+			serialized.OnNext(2)
+			serialized.OnNext(3)
+			// The re-entrant events must not have been delivered yet.
+			if len(result) != 1 {
+				t.Errorf("Expect `result` to equal [1] while delivering 1, got %v", result)
+			}
+		}
+	}, nil, nil))
+
+	serialized.OnNext(1)
+
+	if len(result) != len(expected) {
+		t.Fatalf("Expecting `len(result)` to equal %v got %v", len(expected), len(result))
+	}
+	for i := range expected {
+		if result[i] != expected[i] {
+			t.Errorf("Expect `result[%d]` to equal %v, got %v", i, expected[i], result[i])
+		}
+	}
+}
+
+func TestMergeShouldNotDeliverConcurrently(t *testing.T) {
+	const sources, values = 8, 100
+	signals := make([]interface{}, sources)
+	for i := range signals {
+		signals[i] = NewSignal(func(subscriber Subscriber) {
+			go func() {
+				for v := 0; v < values; v++ {
+					subscriber.OnNext(v)
+				}
+				subscriber.OnCompleted()
+			}()
+		})
+	}
+
+	active := NewAtomic(0)
+	overlapped := NewAtomic(false)
+	count := 0
+	var wg sync.WaitGroup
+	wg.Add(1)
+	NewSignal(func(subscriber Subscriber) {
+		for _, s := range signals {
+			subscriber.OnNext(s)
+		}
+		subscriber.OnCompleted()
+	}).Merge().Subscribe(NewSubscriber(func(_ T) {
+		if active.Modify(func(a interface{}) interface{} { return a.(int) + 1 }).(int) != 0 {
+			overlapped.SetValue(true)
+		}
+		count++
+		active.Modify(func(a interface{}) interface{} { return a.(int) - 1 })
+	}, nil, wg.Done))
+	wg.Wait()
+
+	if overlapped.Value().(bool) {
+		t.Error("Expect values not to be delivered concurrently")
+	}
+	if count != sources*values {
+		t.Errorf("Expect `count` to equal %v, got %v", sources*values, count)
+	}
+}
+
+func TestDelayShouldNotDeliverConcurrently(t *testing.T) {
+	active := NewAtomic(0)
+	overlapped := NewAtomic(false)
+	enter := func() {
+		if active.Modify(func(a interface{}) interface{} { return a.(int) + 1 }).(int) != 0 {
+			overlapped.SetValue(true)
+		}
+		time.Sleep(100 * time.Microsecond)
+		active.Modify(func(a interface{}) interface{} { return a.(int) - 1 })
+	}
+
+	done := make(chan struct{})
+	NewSignal(func(subscriber Subscriber) {
+		go func() {
+			for v := 0; v < 100; v++ {
+				subscriber.OnNext(v)
+			}
+			time.Sleep(2 * time.Millisecond)
+			subscriber.OnError(errors.New("failed"))
+		}()
+	}).Delay(time.Millisecond, NewGoroutineScheduler()).SubscribeFunc(func(_ T) {
+		enter()
+	}, func(_ error) {
+		enter()
+		close(done)
+	}, nil)
+	<-done
+
+	if overlapped.Value().(bool) {
+		t.Error("Expect events not to be delivered concurrently")
+	}
+}